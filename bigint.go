@@ -0,0 +1,103 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Uint128 is an unsigned 128-bit integer.
+type Uint128 struct {
+	Hi uint64 // the most significant 64 bits
+	Lo uint64 // the least significant 64 bits
+}
+
+// Big returns u as a *big.Int.
+func (u Uint128) Big() *big.Int {
+	v := new(big.Int).SetUint64(u.Hi)
+	v.Lsh(v, 64)
+	return v.Or(v, new(big.Int).SetUint64(u.Lo))
+}
+
+// String returns u in decimal.
+func (u Uint128) String() string {
+	return u.Big().String()
+}
+
+// ReadUint128 reads a 128-bit unsigned integer in the byte order of the reader.
+func (r *baseReader) ReadUint128() (Uint128, error) {
+	var b [16]byte
+	if err := r.readFull("ReadUint128", b[:]); err != nil {
+		return Uint128{}, err
+	}
+	order := plainOrder(r.order)
+	if order == binary.BigEndian {
+		return Uint128{Hi: order.Uint64(b[:8]), Lo: order.Uint64(b[8:])}, nil
+	}
+	return Uint128{Hi: order.Uint64(b[8:]), Lo: order.Uint64(b[:8])}, nil
+}
+
+// ReadBigInt reads an integer of size bytes in the byte order of the reader. If
+// signed is set it is two's complement, otherwise unsigned.
+func (r *baseReader) ReadBigInt(size int, signed bool) (*big.Int, error) {
+	if err := r.checkLength("ReadBigInt", r.off, size, 1); err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	if err := r.readFull("ReadBigInt", b); err != nil {
+		return nil, err
+	}
+	if plainOrder(r.order) != binary.BigEndian {
+		slices.Reverse(b)
+	}
+	v := new(big.Int).SetBytes(b)
+	if signed && size > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*size)))
+	}
+	return v, nil
+}
+
+// WriteUint128 writes a 128-bit unsigned integer in the byte order of the writer.
+func (w *baseWriter) WriteUint128(v Uint128) (n int, err error) {
+	var b [16]byte
+	order := plainOrder(w.order)
+	if order == binary.BigEndian {
+		order.PutUint64(b[:8], v.Hi)
+		order.PutUint64(b[8:], v.Lo)
+	} else {
+		order.PutUint64(b[:8], v.Lo)
+		order.PutUint64(b[8:], v.Hi)
+	}
+	return w.write("WriteUint128", b[:])
+}
+
+// WriteBigInt writes v as an integer of size bytes in the byte order of the
+// writer. If signed is set it is written in two's complement, otherwise v must
+// not be negative. It fails with ErrRange, writing nothing, if v does not fit.
+func (w *baseWriter) WriteBigInt(v *big.Int, size int, signed bool) (n int, err error) {
+	bits := 8 * size
+	var fits bool
+	switch {
+	case !signed:
+		fits = v.Sign() >= 0 && v.BitLen() <= bits
+	case v.Sign() >= 0:
+		fits = v.BitLen() < bits
+	default:
+		fits = new(big.Int).Not(v).BitLen() < bits
+	}
+	if !fits {
+		return 0, &OffsetError{Op: "WriteBigInt", Offset: w.off, Width: size, Err: fmt.Errorf("%w: %v in %d bytes", ErrRange, v, size)}
+	}
+
+	u := v
+	if v.Sign() < 0 {
+		u = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		u.Add(u, v)
+	}
+	b := u.FillBytes(make([]byte, size))
+	if plainOrder(w.order) != binary.BigEndian {
+		slices.Reverse(b)
+	}
+	return w.write("WriteBigInt", b)
+}
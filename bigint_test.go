@@ -0,0 +1,118 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestUint128(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	var tests = []struct {
+		name string
+		big  bool
+		want Uint128
+	}{
+		{"BigEndian", true, Uint128{Hi: 0x0102030405060708, Lo: 0x090a0b0c0d0e0f10}},
+		{"LittleEndian", false, Uint128{Hi: 0x100f0e0d0c0b0a09, Lo: 0x0807060504030201}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Uint128
+			var err error
+			buf := &bytes.Buffer{}
+			if tt.big {
+				got, err = NewBigEndianReader(bytes.NewReader(data)).ReadUint128()
+				NewBigEndianWriter(buf).WriteUint128(tt.want)
+			} else {
+				got, err = NewLittleEndianReader(bytes.NewReader(data)).ReadUint128()
+				NewLittleEndianWriter(buf).WriteUint128(tt.want)
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUint128() got = %#v, %v, want %#v", got, err, tt.want)
+			}
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Errorf("WriteUint128() got = %v, want %v", buf.Bytes(), data)
+			}
+		})
+	}
+
+	u := Uint128{Hi: 1, Lo: 2}
+	if got := u.String(); got != "18446744073709551618" {
+		t.Errorf("String() got = %v, want %v", got, "18446744073709551618")
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	var tests = []struct {
+		name   string
+		value  string
+		size   int
+		signed bool
+		be     []byte
+	}{
+		{"Zero", "0", 3, false, []byte{0x00, 0x00, 0x00}},
+		{"Unsigned", "66051", 3, false, []byte{0x01, 0x02, 0x03}},
+		{"UnsignedMax", "255", 1, false, []byte{0xff}},
+		{"SignedPositive", "127", 1, true, []byte{0x7f}},
+		{"SignedMinusOne", "-1", 4, true, []byte{0xff, 0xff, 0xff, 0xff}},
+		{"SignedMin", "-128", 1, true, []byte{0x80}},
+		{"SignedNegative", "-2", 2, true, []byte{0xff, 0xfe}},
+		{"Wide", "340282366920938463463374607431768211455", 16, false, bytes.Repeat([]byte{0xff}, 16)},
+		{"WideSigned", "-170141183460469231731687303715884105728", 16, true, append([]byte{0x80}, make([]byte, 15)...)},
+		{"Empty", "0", 0, false, []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := new(big.Int).SetString(tt.value, 10)
+			le := bytes.Clone(tt.be)
+			for i, j := 0, len(le)-1; i < j; i, j = i+1, j-1 {
+				le[i], le[j] = le[j], le[i]
+			}
+
+			got, err := NewBigEndianReader(bytes.NewReader(tt.be)).ReadBigInt(tt.size, tt.signed)
+			if err != nil || got.Cmp(v) != 0 {
+				t.Errorf("ReadBigInt() big-endian got = %v, %v, want %v", got, err, v)
+			}
+			got, err = NewLittleEndianReader(bytes.NewReader(le)).ReadBigInt(tt.size, tt.signed)
+			if err != nil || got.Cmp(v) != 0 {
+				t.Errorf("ReadBigInt() little-endian got = %v, %v, want %v", got, err, v)
+			}
+
+			buf := &bytes.Buffer{}
+			if _, err := NewBigEndianWriter(buf).WriteBigInt(v, tt.size, tt.signed); err != nil || !bytes.Equal(buf.Bytes(), tt.be) {
+				t.Errorf("WriteBigInt() big-endian got = %v, %v, want %v", buf.Bytes(), err, tt.be)
+			}
+			buf.Reset()
+			if _, err := NewLittleEndianWriter(buf).WriteBigInt(v, tt.size, tt.signed); err != nil || !bytes.Equal(buf.Bytes(), le) {
+				t.Errorf("WriteBigInt() little-endian got = %v, %v, want %v", buf.Bytes(), err, le)
+			}
+		})
+	}
+
+	// Test values out of range
+	t.Run("ErrorCases", func(t *testing.T) {
+		var tests = []struct {
+			value  int64
+			size   int
+			signed bool
+		}{
+			{256, 1, false},
+			{-1, 4, false},
+			{128, 1, true},
+			{-129, 1, true},
+			{1, 0, false},
+		}
+		for _, tt := range tests {
+			buf := &bytes.Buffer{}
+			_, err := NewBigEndianWriter(buf).WriteBigInt(big.NewInt(tt.value), tt.size, tt.signed)
+			if !errors.Is(err, ErrRange) {
+				t.Errorf("WriteBigInt(%v, %v, %v) error = %v, want %v", tt.value, tt.size, tt.signed, err, ErrRange)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("WriteBigInt(%v, %v, %v) wrote %d bytes", tt.value, tt.size, tt.signed, buf.Len())
+			}
+		}
+	})
+}
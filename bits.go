@@ -0,0 +1,234 @@
+package endianio
+
+import (
+	"errors"
+	"io"
+)
+
+// BitOrder defines the order in which bits are taken from each byte.
+type BitOrder int
+
+const (
+	// MSBFirst takes the most significant bit of each byte first, and the first bit
+	// read becomes the most significant bit of the value. This is the order used by
+	// H.264, MPEG and most network protocols.
+	MSBFirst BitOrder = iota
+	// LSBFirst takes the least significant bit of each byte first, and the first bit
+	// read becomes the least significant bit of the value. This is the order used by
+	// DEFLATE and CAN signals in Intel byte order.
+	LSBFirst
+)
+
+// ErrBitCount is returned when more than 64 bits are requested in a single call.
+var ErrBitCount = errors.New("endianio: bit count out of range")
+
+// BitReader reads bit-packed data from an io.Reader, e.g. a BigEndianReader.
+// It only reads from the underlying reader one byte at a time, when it needs
+// more bits, so no data is lost when switching back to the underlying reader after
+// calling Align.
+type BitReader struct {
+	r     io.Reader
+	order BitOrder
+	cur   byte
+	nbits uint // number of unread bits left in cur
+}
+
+// NewBitReader creates a new BitReader reading from the provided io.Reader.
+func NewBitReader(r io.Reader, order BitOrder) *BitReader {
+	return &BitReader{r: r, order: order}
+}
+
+// ReadBits reads n bits, 0 <= n <= 64, and returns them as the low bits of the result.
+func (br *BitReader) ReadBits(n uint) (uint64, error) {
+	if n > 64 {
+		return 0, ErrBitCount
+	}
+	var v uint64
+	var shift uint
+	for left := n; left > 0; {
+		if br.nbits == 0 {
+			var b [1]byte
+			_, err := io.ReadFull(br.r, b[:])
+			if err != nil {
+				if err == io.EOF && left != n {
+					err = io.ErrUnexpectedEOF
+				}
+				return 0, err
+			}
+			br.cur = b[0]
+			br.nbits = 8
+		}
+		k := min(left, br.nbits)
+		mask := uint64(1)<<k - 1
+		if br.order == MSBFirst {
+			v = v<<k | uint64(br.cur>>(br.nbits-k))&mask
+		} else {
+			v |= (uint64(br.cur>>(8-br.nbits)) & mask) << shift
+			shift += k
+		}
+		br.nbits -= k
+		left -= k
+	}
+	return v, nil
+}
+
+// ReadBit reads a single bit.
+func (br *BitReader) ReadBit() (bool, error) {
+	v, err := br.ReadBits(1)
+	return v != 0, err
+}
+
+// ReadUE reads an unsigned Exp-Golomb code, ue(v) in H.264 terms.
+func (br *BitReader) ReadUE() (uint64, error) {
+	var zeros uint
+	for {
+		bit, err := br.ReadBit()
+		if err != nil {
+			if err == io.EOF && zeros > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		if bit {
+			break
+		}
+		zeros++
+		if zeros > 63 {
+			return 0, ErrOverflow
+		}
+	}
+	v, err := br.ReadBits(zeros)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 1<<zeros - 1 + v, nil
+}
+
+// ReadSE reads a signed Exp-Golomb code, se(v) in H.264 terms.
+func (br *BitReader) ReadSE() (int64, error) {
+	k, err := br.ReadUE()
+	if err != nil {
+		return 0, err
+	}
+	if k&1 != 0 {
+		return int64(k>>1) + 1, nil
+	}
+	return -int64(k >> 1), nil
+}
+
+// Aligned reports whether the reader is positioned on a byte boundary.
+func (br *BitReader) Aligned() bool {
+	return br.nbits == 0
+}
+
+// Align discards the remaining bits of the current byte, so the next read starts
+// on a byte boundary.
+func (br *BitReader) Align() {
+	br.nbits = 0
+}
+
+// BitWriter writes bit-packed data to an io.Writer, e.g. a LittleEndianWriter.
+// Bits are written to the underlying writer one byte at a time, as each byte is
+// completed. Call Align to write out a partial final byte.
+type BitWriter struct {
+	w     io.Writer
+	order BitOrder
+	cur   byte
+	nbits uint // number of bits used in cur
+}
+
+// NewBitWriter creates a new BitWriter writing to the provided io.Writer.
+func NewBitWriter(w io.Writer, order BitOrder) *BitWriter {
+	return &BitWriter{w: w, order: order}
+}
+
+// WriteBits writes the low n bits of v, 0 <= n <= 64.
+func (bw *BitWriter) WriteBits(v uint64, n uint) error {
+	if n > 64 {
+		return ErrBitCount
+	}
+	for n > 0 {
+		k := min(n, 8-bw.nbits)
+		mask := uint64(1)<<k - 1
+		if bw.order == MSBFirst {
+			bw.cur |= byte((v>>(n-k))&mask) << (8 - bw.nbits - k)
+		} else {
+			bw.cur |= byte(v&mask) << bw.nbits
+			v >>= k
+		}
+		bw.nbits += k
+		n -= k
+		if bw.nbits == 8 {
+			if err := bw.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteBit writes a single bit.
+func (bw *BitWriter) WriteBit(bit bool) error {
+	if bit {
+		return bw.WriteBits(1, 1)
+	}
+	return bw.WriteBits(0, 1)
+}
+
+// WriteUE writes an unsigned Exp-Golomb code, ue(v) in H.264 terms.
+// math.MaxUint64 cannot be represented and returns ErrOverflow.
+func (bw *BitWriter) WriteUE(v uint64) error {
+	if v == 1<<64-1 {
+		return ErrOverflow
+	}
+	v++
+	var n uint
+	for x := v; x > 1; x >>= 1 {
+		n++
+	}
+	if err := bw.WriteBits(0, n); err != nil {
+		return err
+	}
+	if err := bw.WriteBit(true); err != nil {
+		return err
+	}
+	return bw.WriteBits(v, n)
+}
+
+// WriteSE writes a signed Exp-Golomb code, se(v) in H.264 terms.
+// math.MinInt64 cannot be represented and returns ErrOverflow.
+func (bw *BitWriter) WriteSE(v int64) error {
+	switch {
+	case v > 0:
+		return bw.WriteUE(uint64(v)*2 - 1)
+	case v == -1<<63:
+		return ErrOverflow
+	default:
+		return bw.WriteUE(uint64(-v) * 2)
+	}
+}
+
+// Aligned reports whether the writer is positioned on a byte boundary.
+func (bw *BitWriter) Aligned() bool {
+	return bw.nbits == 0
+}
+
+// Align pads the current byte with zero bits and writes it out, so the next
+// write starts on a byte boundary. It does nothing if the writer is already aligned.
+func (bw *BitWriter) Align() error {
+	if bw.nbits == 0 {
+		return nil
+	}
+	return bw.flush()
+}
+
+func (bw *BitWriter) flush() error {
+	b := [1]byte{bw.cur}
+	bw.cur = 0
+	bw.nbits = 0
+	_, err := bw.w.Write(b[:])
+	return err
+}
@@ -0,0 +1,264 @@
+package endianio
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bitString converts a string of '0' and '1' to bytes, MSB first, zero padded.
+func bitString(s string) []byte {
+	b := make([]byte, (len(s)+7)/8)
+	for i, c := range s {
+		if c == '1' {
+			b[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return b
+}
+
+func TestBitReader(t *testing.T) {
+	// Test ReadBits in MSB first order
+	t.Run("ReadBitsMSBFirst", func(t *testing.T) {
+		data := []byte{0b10110100, 0b01111111, 0xAB, 0xCD}
+		br := NewBitReader(bytes.NewReader(data), MSBFirst)
+		var tests = []struct {
+			n    uint
+			want uint64
+		}{
+			{1, 0b1},
+			{3, 0b011},
+			{6, 0b010001},
+			{6, 0b111111},
+			{16, 0xABCD},
+		}
+		for _, tt := range tests {
+			got, err := br.ReadBits(tt.n)
+			if err != nil {
+				t.Fatalf("ReadBits(%d) error = %v", tt.n, err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadBits(%d) got = %b, want %b", tt.n, got, tt.want)
+			}
+		}
+		if _, err := br.ReadBits(1); err != io.EOF {
+			t.Errorf("ReadBits() error = %v, want %v", err, io.EOF)
+		}
+	})
+
+	// Test ReadBits in LSB first order
+	t.Run("ReadBitsLSBFirst", func(t *testing.T) {
+		data := []byte{0b10110100, 0b01111111, 0xCD, 0xAB}
+		br := NewBitReader(bytes.NewReader(data), LSBFirst)
+		var tests = []struct {
+			n    uint
+			want uint64
+		}{
+			{3, 0b100},
+			{5, 0b10110},
+			{4, 0b1111},
+			{6, 0b010111},
+			{14, 0b10101011110011},
+		}
+		for _, tt := range tests {
+			got, err := br.ReadBits(tt.n)
+			if err != nil {
+				t.Fatalf("ReadBits(%d) error = %v", tt.n, err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadBits(%d) got = %b, want %b", tt.n, got, tt.want)
+			}
+		}
+	})
+
+	// Test reading 64 bits from an unaligned position
+	t.Run("ReadBits64", func(t *testing.T) {
+		data := []byte{0x0F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xF0}
+		br := NewBitReader(bytes.NewReader(data), MSBFirst)
+		br.ReadBits(4)
+		got, err := br.ReadBits(64)
+		if err != nil {
+			t.Fatalf("ReadBits(64) error = %v", err)
+		}
+		if got != math.MaxUint64 {
+			t.Errorf("ReadBits(64) got = %x, want %x", got, uint64(math.MaxUint64))
+		}
+		if _, err := br.ReadBits(65); err != ErrBitCount {
+			t.Errorf("ReadBits(65) error = %v, want %v", err, ErrBitCount)
+		}
+	})
+
+	// Test Exp-Golomb codes from the H.264 specification, table 9-2
+	t.Run("ReadUE", func(t *testing.T) {
+		data := bitString("1" + "010" + "011" + "00100" + "00101" + "00110" + "00111" + "0001000" + "0001001")
+		br := NewBitReader(bytes.NewReader(data), MSBFirst)
+		for want := uint64(0); want <= 8; want++ {
+			got, err := br.ReadUE()
+			if err != nil {
+				t.Fatalf("ReadUE() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("ReadUE() got = %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("ReadSE", func(t *testing.T) {
+		data := bitString("1" + "010" + "011" + "00100" + "00101" + "00110" + "00111")
+		br := NewBitReader(bytes.NewReader(data), MSBFirst)
+		for _, want := range []int64{0, 1, -1, 2, -2, 3, -3} {
+			got, err := br.ReadSE()
+			if err != nil {
+				t.Fatalf("ReadSE() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("ReadSE() got = %v, want %v", got, want)
+			}
+		}
+	})
+
+	// Test that Align leaves the underlying reader usable
+	t.Run("Align", func(t *testing.T) {
+		r := NewBigEndianReader(bytes.NewReader([]byte{0xFF, 0x12, 0x34}))
+		br := NewBitReader(r, MSBFirst)
+		br.ReadBits(3)
+		if br.Aligned() {
+			t.Errorf("Aligned() = true after reading 3 bits")
+		}
+		br.Align()
+		if !br.Aligned() {
+			t.Errorf("Aligned() = false after Align()")
+		}
+		got, err := r.ReadUint16()
+		if err != nil {
+			t.Fatalf("ReadUint16() error = %v", err)
+		}
+		if got != 0x1234 {
+			t.Errorf("ReadUint16() got = 0x%04X, want 0x1234", got)
+		}
+	})
+
+	t.Run("UnexpectedEOF", func(t *testing.T) {
+		br := NewBitReader(bytes.NewReader([]byte{0xFF}), MSBFirst)
+		if _, err := br.ReadBits(12); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadBits() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		br = NewBitReader(bytes.NewReader([]byte{0x00}), MSBFirst)
+		if _, err := br.ReadUE(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadUE() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+
+	t.Run("FailingReader", func(t *testing.T) {
+		br := NewBitReader(&failingReader{}, MSBFirst)
+		if _, err := br.ReadBits(1); err == nil {
+			t.Errorf("ReadBits() expected error for failing reader")
+		}
+	})
+}
+
+func TestBitWriter(t *testing.T) {
+	// Test WriteBits in both bit orders
+	t.Run("WriteBits", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			order BitOrder
+			want  []byte
+		}{
+			{"MSBFirst", MSBFirst, []byte{0b10110100, 0b01111111, 0xAB, 0xC0}},
+			{"LSBFirst", LSBFirst, []byte{0b00010111, 0b11111101, 0xBC, 0x0A}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				bw := NewBitWriter(buf, tt.order)
+				bw.WriteBits(0b1, 1)
+				bw.WriteBits(0b011, 3)
+				bw.WriteBits(0b010001, 6)
+				bw.WriteBits(0b111111, 6)
+				bw.WriteBits(0xABC, 12)
+				if err := bw.Align(); err != nil {
+					t.Fatalf("Align() error = %v", err)
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteBits() got = %08b, want %08b", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test Exp-Golomb codes from the H.264 specification, table 9-2
+	t.Run("WriteUE", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		bw := NewBitWriter(buf, MSBFirst)
+		for v := uint64(0); v <= 8; v++ {
+			if err := bw.WriteUE(v); err != nil {
+				t.Fatalf("WriteUE() error = %v", err)
+			}
+		}
+		bw.Align()
+		want := bitString("1" + "010" + "011" + "00100" + "00101" + "00110" + "00111" + "0001000" + "0001001")
+		if got := buf.Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("WriteUE() got = %08b, want %08b", got, want)
+		}
+		if err := bw.WriteUE(math.MaxUint64); err != ErrOverflow {
+			t.Errorf("WriteUE() error = %v, want %v", err, ErrOverflow)
+		}
+		if err := bw.WriteSE(math.MinInt64); err != ErrOverflow {
+			t.Errorf("WriteSE() error = %v, want %v", err, ErrOverflow)
+		}
+	})
+
+	t.Run("FailingWriter", func(t *testing.T) {
+		bw := NewBitWriter(&failingWriter{}, MSBFirst)
+		if err := bw.WriteBits(0xFF, 8); err == nil {
+			t.Errorf("WriteBits() expected error for failing writer")
+		}
+	})
+
+	// Test random round trips through BitReader
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+			rng := rand.New(rand.NewSource(1))
+			type field struct {
+				v uint64
+				n uint
+			}
+			fields := make([]field, 1000)
+			buf := &bytes.Buffer{}
+			bw := NewBitWriter(buf, order)
+			for i := range fields {
+				n := uint(rng.Intn(65))
+				v := rng.Uint64()
+				if n < 64 {
+					v &= 1<<n - 1
+				}
+				fields[i] = field{v, n}
+				bw.WriteBits(v, n)
+			}
+			bw.WriteUE(12345)
+			bw.WriteSE(-6789)
+			bw.Align()
+
+			br := NewBitReader(buf, order)
+			for _, f := range fields {
+				got, err := br.ReadBits(f.n)
+				if err != nil {
+					t.Fatalf("ReadBits(%d) error = %v", f.n, err)
+				}
+				if got != f.v {
+					t.Fatalf("ReadBits(%d) got = %x, want %x", f.n, got, f.v)
+				}
+			}
+			if got, _ := br.ReadUE(); got != 12345 {
+				t.Errorf("ReadUE() got = %v, want 12345", got)
+			}
+			if got, _ := br.ReadSE(); got != -6789 {
+				t.Errorf("ReadSE() got = %v, want -6789", got)
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package endianio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// ErrChecksum is returned by ReadAndVerifyChecksum when the checksum read does
+// not match the one computed.
+var ErrChecksum = errors.New("endianio: checksum mismatch")
+
+// SetHash attaches h to the reader, so every byte read from then on, by any
+// method, is also written to h. A nil h detaches it. The hash is not reset.
+func (r *baseReader) SetHash(h hash.Hash) {
+	r.hash = h
+}
+
+// Sum appends the current checksum of the attached hash to b. It panics if no
+// hash is attached.
+func (r *baseReader) Sum(b []byte) []byte {
+	return attachedHash(r.hash).Sum(b)
+}
+
+// ResetHash resets the attached hash, so the next checksum covers the bytes read
+// from now on. It panics if no hash is attached.
+func (r *baseReader) ResetHash() {
+	attachedHash(r.hash).Reset()
+}
+
+// ReadAndVerifyChecksum reads the checksum of the attached hash, as stored after
+// the data it covers, and fails with ErrChecksum if it differs from the one
+// computed over the bytes read so far. Checksums of 2, 4 or 8 bytes, such as
+// CRC-32 and Adler-32, are read as an integer in the byte order of the reader;
+// others are read as is. The checksum itself is not hashed. It panics if no
+// hash is attached.
+func (r *baseReader) ReadAndVerifyChecksum() error {
+	h := attachedHash(r.hash)
+	want := checksumBytes(r.order, h.Sum(nil))
+	off := r.off
+	got := make([]byte, len(want))
+	r.hash = nil
+	err := r.readFull("ReadAndVerifyChecksum", got)
+	r.hash = h
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, want) {
+		return &OffsetError{Op: "ReadAndVerifyChecksum", Offset: off, Width: len(got), N: len(got), Err: fmt.Errorf("%w: read %x, computed %x", ErrChecksum, got, want)}
+	}
+	return nil
+}
+
+// SetHash attaches h to the writer, so every byte written from then on, by any
+// method, is also written to h. A nil h detaches it. The hash is not reset.
+func (w *baseWriter) SetHash(h hash.Hash) {
+	w.hash = h
+}
+
+// Sum appends the current checksum of the attached hash to b. It panics if no
+// hash is attached.
+func (w *baseWriter) Sum(b []byte) []byte {
+	return attachedHash(w.hash).Sum(b)
+}
+
+// ResetHash resets the attached hash, so the next checksum covers the bytes
+// written from now on. It panics if no hash is attached.
+func (w *baseWriter) ResetHash() {
+	attachedHash(w.hash).Reset()
+}
+
+// WriteChecksum writes the checksum of the attached hash over the bytes written
+// so far, laid out as ReadAndVerifyChecksum reads it. The checksum itself is not
+// hashed. It panics if no hash is attached.
+func (w *baseWriter) WriteChecksum() (n int, err error) {
+	h := attachedHash(w.hash)
+	b := checksumBytes(w.order, h.Sum(nil))
+	w.hash = nil
+	n, err = w.write("WriteChecksum", b)
+	w.hash = h
+	return n, err
+}
+
+func attachedHash(h hash.Hash) hash.Hash {
+	if h == nil {
+		panic("endianio: no hash attached")
+	}
+	return h
+}
+
+// checksumBytes returns the checksum sum, which hash.Hash returns big-endian,
+// laid out in the byte order order if it is a 2, 4 or 8-byte integer.
+func checksumBytes(order binary.ByteOrder, sum []byte) []byte {
+	switch len(sum) {
+	case 2:
+		order.PutUint16(sum, binary.BigEndian.Uint16(sum))
+	case 4:
+		order.PutUint32(sum, binary.BigEndian.Uint32(sum))
+	case 8:
+		order.PutUint64(sum, binary.BigEndian.Uint64(sum))
+	}
+	return sum
+}
@@ -0,0 +1,156 @@
+package endianio
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	// The payload is written and read with a mix of methods, which must all be hashed
+	write := func(w *LittleEndianWriter) {
+		w.WriteUint32(0xdeadbeef)
+		w.WriteUint8(7)
+		w.WriteStringPrefixed(PrefixUint16, "hello")
+		w.Write([]byte{1, 2, 3})
+	}
+	read := func(r *LittleEndianReader) error {
+		if _, err := r.ReadUint32(); err != nil {
+			return err
+		}
+		if _, err := r.ReadByte(); err != nil {
+			return err
+		}
+		if _, err := r.ReadStringPrefixed(PrefixUint16, -1); err != nil {
+			return err
+		}
+		_, err := io.ReadFull(r, make([]byte, 3))
+		return err
+	}
+	payload := []byte{0xef, 0xbe, 0xad, 0xde, 7, 5, 0, 'h', 'e', 'l', 'l', 'o', 1, 2, 3}
+
+	buf := &bytes.Buffer{}
+	w := NewLittleEndianWriter(buf)
+	w.SetHash(crc32.NewIEEE())
+	write(w)
+	if n, err := w.WriteChecksum(); n != 4 || err != nil {
+		t.Fatalf("WriteChecksum() got = %d, %v, want 4, nil", n, err)
+	}
+	want := binary.LittleEndian.AppendUint32(bytes.Clone(payload), crc32.ChecksumIEEE(payload))
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("WriteChecksum() wrote %x, want %x", buf.Bytes(), want)
+	}
+	if got := w.Sum(nil); !bytes.Equal(got, binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(payload))) {
+		t.Errorf("Sum() after WriteChecksum() got = %x, the checksum was hashed", got)
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(want))
+		r.SetHash(crc32.NewIEEE())
+		if err := read(r); err != nil {
+			t.Fatalf("read error = %v", err)
+		}
+		if err := r.ReadAndVerifyChecksum(); err != nil {
+			t.Errorf("ReadAndVerifyChecksum() error = %v, want nil", err)
+		}
+		if r.Offset() != int64(len(want)) {
+			t.Errorf("Offset() got = %d, want %d", r.Offset(), len(want))
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		corrupt := bytes.Clone(want)
+		corrupt[8] ^= 0x20
+		r := NewLittleEndianReader(bytes.NewReader(corrupt))
+		r.SetHash(crc32.NewIEEE())
+		read(r)
+		err := r.ReadAndVerifyChecksum()
+		var oe *OffsetError
+		if !errors.Is(err, ErrChecksum) || !errors.As(err, &oe) || oe.Offset != int64(len(payload)) {
+			t.Errorf("ReadAndVerifyChecksum() error = %v, want %v at offset %d", err, ErrChecksum, len(payload))
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(want[:len(want)-2]))
+		r.SetHash(crc32.NewIEEE())
+		read(r)
+		if err := r.ReadAndVerifyChecksum(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadAndVerifyChecksum() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestChecksumByteOrderAndSize(t *testing.T) {
+	data := []byte("endianio")
+	sha := sha256.Sum256(data)
+	var tests = []struct {
+		name    string
+		order   binary.ByteOrder
+		newHash func() hash.Hash
+		want    []byte
+	}{
+		{"Adler32BigEndian", binary.BigEndian, func() hash.Hash { return adler32.New() }, binary.BigEndian.AppendUint32(nil, adler32.Checksum(data))},
+		{"Adler32LittleEndian", binary.LittleEndian, func() hash.Hash { return adler32.New() }, binary.LittleEndian.AppendUint32(nil, adler32.Checksum(data))},
+		{"Adler32CDAB", OrderCDAB, func() hash.Hash { return adler32.New() }, []byte{0x03, 0x48, 0x0e, 0xa6}}, // 0x0ea60348,
+		{"CRC64LittleEndian", binary.LittleEndian, func() hash.Hash { return crc64.New(crc64.MakeTable(crc64.ECMA)) }, binary.LittleEndian.AppendUint64(nil, crc64.Checksum(data, crc64.MakeTable(crc64.ECMA)))},
+		{"SHA256", binary.LittleEndian, sha256.New, sha[:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := NewByteOrderWriter(buf, tt.order)
+			w.SetHash(tt.newHash())
+			w.Write(data)
+			w.WriteChecksum()
+			if got := buf.Bytes()[len(data):]; !bytes.Equal(got, tt.want) {
+				t.Errorf("WriteChecksum() got = %x, want %x", got, tt.want)
+			}
+
+			r := NewByteOrderReader(buf, tt.order)
+			r.SetHash(tt.newHash())
+			io.ReadFull(r, make([]byte, len(data)))
+			if err := r.ReadAndVerifyChecksum(); err != nil {
+				t.Errorf("ReadAndVerifyChecksum() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestResetHash(t *testing.T) {
+	r := NewBigEndianReader(bytes.NewReader([]byte{1, 2, 3, 4, 5, 6}))
+	r.SetHash(crc32.NewIEEE())
+	r.ReadUint16()
+	r.ResetHash()
+	r.ReadUint16()
+	r.SetHash(nil)
+	r.ReadUint16()
+	r.SetHash(crc32.NewIEEE())
+	if got, want := r.Sum(nil), crc32.NewIEEE().Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Sum() of a new hash got = %x, want %x", got, want)
+	}
+
+	h := crc32.NewIEEE()
+	r = NewBigEndianReader(bytes.NewReader([]byte{1, 2, 3, 4}))
+	r.SetHash(h)
+	r.ReadUint16()
+	r.ResetHash()
+	r.ReadUint16()
+	if got, want := h.Sum32(), crc32.ChecksumIEEE([]byte{3, 4}); got != want {
+		t.Errorf("Sum32() after ResetHash() got = %#x, want %#x", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ReadAndVerifyChecksum() without a hash did not panic")
+		}
+	}()
+	NewBigEndianReader(bytes.NewReader(nil)).ReadAndVerifyChecksum()
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// basicMethods maps the supported basic types to the suffix of their
+// EndianReader/EndianWriter methods.
+var basicMethods = map[string]string{
+	"bool":    "Uint8",
+	"byte":    "Uint8",
+	"uint8":   "Uint8",
+	"uint16":  "Uint16",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"int8":    "Int8",
+	"int16":   "Int16",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"float32": "Float32",
+	"float64": "Float64",
+}
+
+// Generator generates ReadFrom/WriteTo methods for the struct types of a single package.
+type Generator struct {
+	pkg     string
+	decls   map[string]ast.Expr // type name -> type expression of all types in the package
+	methods map[string]bool     // types that already have a ReadFrom method
+	queue   []string            // types to generate, in order
+	queued  map[string]bool
+
+	buf    bytes.Buffer
+	usesEr bool // the method being generated needs an err variable
+}
+
+// NewGenerator creates a Generator for the parsed files of a package.
+func NewGenerator(files []*ast.File) (*Generator, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files to parse")
+	}
+	g := &Generator{
+		pkg:     files[0].Name.Name,
+		decls:   make(map[string]ast.Expr),
+		methods: make(map[string]bool),
+		queued:  make(map[string]bool),
+	}
+	for _, f := range files {
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range d.Specs {
+					ts := s.(*ast.TypeSpec)
+					g.decls[ts.Name.Name] = ts.Type
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) == 1 && d.Name.Name == "ReadFrom" {
+					g.methods[receiverName(d.Recv.List[0].Type)] = true
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+func receiverName(e ast.Expr) string {
+	if s, ok := e.(*ast.StarExpr); ok {
+		e = s.X
+	}
+	if id, ok := e.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// Generate returns the formatted source of the methods for the named types, and
+// for any struct types they use that do not already have methods.
+// cmdline is recorded in the generated file header.
+func (g *Generator) Generate(typeNames []string, cmdline string) ([]byte, error) {
+	for _, name := range typeNames {
+		if _, ok := g.decls[name].(*ast.StructType); !ok {
+			return nil, fmt.Errorf("type %s is not a struct type in package %s", name, g.pkg)
+		}
+		g.enqueue(name)
+	}
+
+	var body bytes.Buffer
+	for i := 0; i < len(g.queue); i++ {
+		name := g.queue[i]
+		st := g.decls[name].(*ast.StructType)
+		if err := g.generateRead(name, st); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+		if err := g.generateWrite(name, st); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by \"%s\"; DO NOT EDIT.\n\n", cmdline)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg)
+	fmt.Fprintf(&out, "import \"github.com/noselasd/endianio\"\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+func (g *Generator) enqueue(name string) {
+	if !g.queued[name] {
+		g.queued[name] = true
+		g.queue = append(g.queue, name)
+	}
+}
+
+// field is a struct field prepared for code generation.
+type field struct {
+	name  string // "_" for blank fields
+	typ   ast.Expr
+	len   string
+	order string
+}
+
+func (g *Generator) fields(typeName string, st *ast.StructType) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Get("endian")
+		}
+		var ff field
+		ff.typ = f.Type
+		skip := false
+		for _, opt := range strings.Split(tag, ",") {
+			switch {
+			case opt == "skip":
+				skip = true
+			case opt == "big" || opt == "little":
+				ff.order = opt
+			case strings.HasPrefix(opt, "len="):
+				ff.len = strings.TrimPrefix(opt, "len=")
+			case opt == "":
+			default:
+				return nil, fmt.Errorf("%s: unknown endian tag option %q", typeName, opt)
+			}
+		}
+		if skip {
+			continue
+		}
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded fields are not supported", typeName)
+		}
+		for _, n := range f.Names {
+			if n.Name != "_" && !n.IsExported() {
+				continue
+			}
+			ff.name = n.Name
+			fields = append(fields, ff)
+		}
+	}
+	return fields, nil
+}
+
+// override opens a block declaring the reader or writer for a byte order
+// override of the field, named by v and kind, and returns its name, or returns
+// v if the field has no override. The reader or writer is created where the
+// field is read or written, so its offsets match the stream at that point.
+func (g *Generator) override(buf *bytes.Buffer, v, kind, order string) string {
+	var name, typ string
+	switch order {
+	case "big":
+		name, typ = "be", "Big"
+	case "little":
+		name, typ = "le", "Little"
+	default:
+		return v
+	}
+	fmt.Fprintf(buf, "{\n%s, err := endianio.As%sEndian%s(%s)\nif err != nil {\nreturn err\n}\n", name, typ, kind, v)
+	return name
+}
+
+func (g *Generator) generateRead(name string, st *ast.StructType) error {
+	fields, err := g.fields(name, st)
+	if err != nil {
+		return err
+	}
+	g.usesEr = false
+
+	var body bytes.Buffer
+	for _, f := range fields {
+		acc := "v." + f.name
+		if f.name == "_" {
+			// Basic types, named or not, are read into _ and discarded
+			if basic, _, _, _, _ := g.resolve(f.typ); basic != "" {
+				acc = "_"
+			} else {
+				acc = "blank"
+				fmt.Fprintf(&body, "{\nvar blank %s\n", types.ExprString(f.typ))
+			}
+		}
+		// An override block declares its own err
+		usesEr := g.usesEr
+		r := g.override(&body, "r", "Reader", f.order)
+		if at, ok := f.typ.(*ast.ArrayType); ok && at.Len == nil {
+			if f.len == "" {
+				return fmt.Errorf("%s.%s: slice field needs a len tag", name, f.name)
+			}
+			// MakeSlice checks the length, and the slice grows as the
+			// elements are read, like Decode
+			g.usesEr = true
+			elt := types.ExprString(at.Elt)
+			fmt.Fprintf(&body, "if %s, err = endianio.MakeSlice[%s](r, v.%s); err != nil {\nreturn err\n}\n", acc, elt, f.len)
+			fmt.Fprintf(&body, "for i := 0; i < int(v.%s); i++ {\nvar elem %s\n", f.len, elt)
+			if err := g.read(&body, r, "elem", at.Elt, 1); err != nil {
+				return fmt.Errorf("%s.%s: %v", name, f.name, err)
+			}
+			fmt.Fprintf(&body, "%s = append(%s, elem)\n}\n", acc, acc)
+		} else if err := g.read(&body, r, acc, f.typ, 0); err != nil {
+			return fmt.Errorf("%s.%s: %v", name, f.name, err)
+		}
+		if f.order != "" {
+			body.WriteString("}\n")
+			g.usesEr = usesEr
+		}
+		if acc == "blank" {
+			body.WriteString("}\n")
+		}
+	}
+
+	g.buf.Reset()
+	fmt.Fprintf(&g.buf, "\n// ReadFrom reads v from r.\n")
+	fmt.Fprintf(&g.buf, "func (v *%s) ReadFrom(r endianio.EndianReader) error {\n", name)
+	g.dispatch("r", "Reader", body.Bytes())
+	g.buf.WriteString("return nil\n}\n")
+	return nil
+}
+
+func (g *Generator) generateWrite(name string, st *ast.StructType) error {
+	fields, err := g.fields(name, st)
+	if err != nil {
+		return err
+	}
+	g.usesEr = false
+
+	var body bytes.Buffer
+	for _, f := range fields {
+		acc := "v." + f.name
+		if f.name == "_" {
+			acc = "blank"
+			fmt.Fprintf(&body, "{\nvar blank %s\n", types.ExprString(f.typ))
+		}
+		usesEr := g.usesEr
+		w := g.override(&body, "w", "Writer", f.order)
+		if at, ok := f.typ.(*ast.ArrayType); ok && at.Len == nil {
+			if f.len == "" {
+				return fmt.Errorf("%s.%s: slice field needs a len tag", name, f.name)
+			}
+			fmt.Fprintf(&body, "if len(%s) != int(v.%s) {\nreturn endianio.ErrLengthMismatch\n}\n", acc, f.len)
+			fmt.Fprintf(&body, "for i := range %s {\n", acc)
+			if err := g.write(&body, w, acc+"[i]", at.Elt, 1); err != nil {
+				return fmt.Errorf("%s.%s: %v", name, f.name, err)
+			}
+			body.WriteString("}\n")
+		} else if err := g.write(&body, w, acc, f.typ, 0); err != nil {
+			return fmt.Errorf("%s.%s: %v", name, f.name, err)
+		}
+		if f.order != "" {
+			body.WriteString("}\n")
+			g.usesEr = usesEr
+		}
+		if acc == "blank" {
+			body.WriteString("}\n")
+		}
+	}
+
+	g.buf.Reset()
+	fmt.Fprintf(&g.buf, "\n// WriteTo writes v to w.\n")
+	fmt.Fprintf(&g.buf, "func (v *%s) WriteTo(w endianio.EndianWriter) error {\n", name)
+	g.dispatch("w", "Writer", body.Bytes())
+	g.buf.WriteString("return nil\n}\n")
+	return nil
+}
+
+// dispatch writes the method body, declaring err if needed. The body is
+// repeated for the big and little-endian readers or writers of the package, so
+// that the calls in it are direct rather than through the EndianReader or
+// EndianWriter interface, with a default case for anything else.
+func (g *Generator) dispatch(v, kind string, body []byte) {
+	if g.usesEr {
+		g.buf.WriteString("var err error\n")
+	}
+	if len(body) == 0 {
+		return
+	}
+	fmt.Fprintf(&g.buf, "switch %s := %s.(type) {\n", v, v)
+	for _, typ := range []string{"Big", "Little"} {
+		fmt.Fprintf(&g.buf, "case *endianio.%sEndian%s:\n", typ, kind)
+		g.buf.Write(body)
+	}
+	g.buf.WriteString("default:\n")
+	g.buf.Write(body)
+	g.buf.WriteString("}\n")
+}
+
+var indexVars = []string{"i", "j", "k", "l", "m", "n"}
+
+// resolve returns the basic type name and conversion needed for t, or the
+// underlying type expression if t is not a basic type. nested is set for
+// struct types, which are read with their own methods.
+func (g *Generator) resolve(t ast.Expr) (basic, conv string, under ast.Expr, nested bool, err error) {
+	id, ok := t.(*ast.Ident)
+	if !ok {
+		return "", "", t, false, nil
+	}
+	if _, ok := basicMethods[id.Name]; ok {
+		return id.Name, "", nil, false, nil
+	}
+	decl, ok := g.decls[id.Name]
+	if !ok {
+		return "", "", nil, false, fmt.Errorf("unsupported type %s", id.Name)
+	}
+	switch d := decl.(type) {
+	case *ast.StructType:
+		if !g.methods[id.Name] {
+			g.enqueue(id.Name)
+		}
+		return "", "", nil, true, nil
+	case *ast.Ident:
+		if _, ok := basicMethods[d.Name]; ok {
+			return d.Name, id.Name, nil, false, nil
+		}
+	case *ast.ArrayType:
+		if d.Len != nil {
+			return "", "", d, false, nil
+		}
+	}
+	return "", "", nil, false, fmt.Errorf("unsupported type %s", id.Name)
+}
+
+func (g *Generator) read(buf *bytes.Buffer, r, acc string, t ast.Expr, depth int) error {
+	basic, conv, under, nested, err := g.resolve(t)
+	if err != nil {
+		return err
+	}
+	switch {
+	case nested:
+		g.usesEr = true
+		fmt.Fprintf(buf, "if err = %s.ReadFrom(%s); err != nil {\nreturn err\n}\n", acc, r)
+	case basic == "bool":
+		val := "x != 0"
+		if conv != "" {
+			val = conv + "(" + val + ")"
+		}
+		fmt.Fprintf(buf, "{\nx, err := %s.ReadUint8()\nif err != nil {\nreturn err\n}\n%s = %s\n}\n", r, acc, val)
+	case basic != "" && conv != "":
+		fmt.Fprintf(buf, "{\nx, err := %s.Read%s()\nif err != nil {\nreturn err\n}\n%s = %s(x)\n}\n", r, basicMethods[basic], acc, conv)
+	case basic != "":
+		g.usesEr = true
+		fmt.Fprintf(buf, "if %s, err = %s.Read%s(); err != nil {\nreturn err\n}\n", acc, r, basicMethods[basic])
+	default:
+		at, ok := under.(*ast.ArrayType)
+		if !ok || at.Len == nil {
+			return fmt.Errorf("unsupported type %s", types.ExprString(t))
+		}
+		if depth >= len(indexVars) {
+			return fmt.Errorf("arrays nested too deeply")
+		}
+		i := indexVars[depth]
+		fmt.Fprintf(buf, "for %s := range %s {\n", i, acc)
+		if err := g.read(buf, r, acc+"["+i+"]", at.Elt, depth+1); err != nil {
+			return err
+		}
+		buf.WriteString("}\n")
+	}
+	return nil
+}
+
+func (g *Generator) write(buf *bytes.Buffer, w, acc string, t ast.Expr, depth int) error {
+	basic, conv, under, nested, err := g.resolve(t)
+	if err != nil {
+		return err
+	}
+	switch {
+	case nested:
+		g.usesEr = true
+		fmt.Fprintf(buf, "if err = %s.WriteTo(%s); err != nil {\nreturn err\n}\n", acc, w)
+	case basic == "bool":
+		g.usesEr = true
+		fmt.Fprintf(buf, "{\nvar x uint8\nif %s {\nx = 1\n}\nif _, err = %s.WriteUint8(x); err != nil {\nreturn err\n}\n}\n", acc, w)
+	case basic != "":
+		g.usesEr = true
+		val := acc
+		if conv != "" {
+			val = basic + "(" + acc + ")"
+		}
+		fmt.Fprintf(buf, "if _, err = %s.Write%s(%s); err != nil {\nreturn err\n}\n", w, basicMethods[basic], val)
+	default:
+		at, ok := under.(*ast.ArrayType)
+		if !ok || at.Len == nil {
+			return fmt.Errorf("unsupported type %s", types.ExprString(t))
+		}
+		if depth >= len(indexVars) {
+			return fmt.Errorf("arrays nested too deeply")
+		}
+		i := indexVars[depth]
+		fmt.Fprintf(buf, "for %s := range %s {\n", i, acc)
+		if err := g.write(buf, w, acc+"["+i+"]", at.Elt, depth+1); err != nil {
+			return err
+		}
+		buf.WriteString("}\n")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func generateTestdata(t *testing.T) []byte {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join("testdata", "header.go"), nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator([]*ast.File{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := g.Generate([]string{"Header", "Empty", "Samples"}, "endiangen -type Header,Empty,Samples")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return src
+}
+
+func TestGolden(t *testing.T) {
+	got := generateTestdata(t)
+	golden := filepath.Join("testdata", "header.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code does not match %s, run go test -update to update it\n%s", golden, got)
+	}
+}
+
+// TestGeneratedCode builds the generated code in a temporary module and runs
+// testdata/header_test.go against it.
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of generated code in short mode")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	gomod := "module example.com/testdata\n\ngo 1.24\n\n" +
+		"require github.com/noselasd/endianio v0.0.0\n\n" +
+		"replace github.com/noselasd/endianio => " + root + "\n"
+	files := map[string][]byte{
+		"go.mod":           []byte(gomod),
+		"header_endian.go": generateTestdata(t),
+	}
+	for _, name := range []string{"header.go", "header_test.go"} {
+		src, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = src
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gocmd := filepath.Join(runtime.GOROOT(), "bin", "go")
+	for _, args := range [][]string{{"vet", "."}, {"test", "."}} {
+		cmd := exec.Command(gocmd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s: %v\n%s", args[0], err, out)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Command endiangen generates ReadFrom and WriteTo methods for struct types, which
+// read and write the struct through an endianio.EndianReader/EndianWriter without
+// using reflection.
+//
+// The struct fields and endian struct tags supported are the same as for
+// endianio.Decode and endianio.Encode. Struct types used by the named types are
+// generated as well, unless they already have a ReadFrom method.
+//
+// Usage:
+//
+//	endiangen -type Header[,Type...] [-output file] [file.go... | directory]
+//
+// It is intended to be run by go generate, from a file in the package declaring the types:
+//
+//	//go:generate go run github.com/noselasd/endianio/cmd/endiangen -type Header
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output    = flag.String("output", "", "output file name; default <directory>/<type>_endian.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of endiangen:\n")
+	fmt.Fprintf(os.Stderr, "\tendiangen -type T[,T...] [-output file] [file.go... | directory]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("endiangen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	dir := args[0]
+	if len(args) > 1 || !isDirectory(dir) {
+		dir = filepath.Dir(args[0])
+	}
+	outName := *output
+	if outName == "" {
+		outName = filepath.Join(dir, strings.ToLower(types[0])+"_endian.go")
+	}
+
+	files, err := parseFiles(args, outName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := NewGenerator(files)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := g.Generate(types, "endiangen "+strings.Join(os.Args[1:], " "))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func isDirectory(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.IsDir()
+}
+
+// parseFiles parses the named Go files, or the non-test Go files in the named
+// directory, skipping the output file so stale generated code is ignored.
+func parseFiles(args []string, outName string) ([]*ast.File, error) {
+	names := args
+	if len(args) == 1 && isDirectory(args[0]) {
+		matches, err := filepath.Glob(filepath.Join(args[0], "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		names = nil
+		for _, m := range matches {
+			if !strings.HasSuffix(m, "_test.go") {
+				names = append(names, m)
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if filepath.Clean(name) == filepath.Clean(outName) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,40 @@
+package testdata
+
+type Kind uint8
+
+type Enabled bool
+
+type MAC [6]byte
+
+type Point struct {
+	X, Y int16
+}
+
+type Header struct {
+	Magic   uint32 `endian:"big"`
+	Version uint16
+	Kind    Kind
+	Enabled Enabled
+	Flags   bool
+	_       [3]byte
+	_       uint16
+	_       Kind
+	Scale   float32
+	Ratio   float64 `endian:"little"`
+	Addr    MAC
+	Origin  Point
+	Corners [2]Point `endian:"big"`
+	Matrix  [2][3]int8
+	Count   uint16
+	Offsets []uint32 `endian:"len=Count"`
+	Points  []Point  `endian:"len=Count,big"`
+	Cache   []byte   `endian:"skip"`
+	private uint64
+}
+
+type Empty struct{}
+
+type Samples struct {
+	N      int8
+	Values []uint8 `endian:"len=N"`
+}
@@ -0,0 +1,89 @@
+package testdata
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/noselasd/endianio"
+)
+
+// TestGenerated checks the generated methods against the reflection based codec.
+func TestGenerated(t *testing.T) {
+	h := Header{
+		Magic:   0xCAFEBABE,
+		Version: 2,
+		Kind:    7,
+		Enabled: true,
+		Scale:   1.5,
+		Ratio:   -0.25,
+		Addr:    MAC{1, 2, 3, 4, 5, 6},
+		Origin:  Point{-1, 2},
+		Corners: [2]Point{{3, -4}, {5, -6}},
+		Matrix:  [2][3]int8{{1, -2, 3}, {-4, 5, -6}},
+		Count:   2,
+		Offsets: []uint32{0x12345678, 0x9ABCDEF0},
+		Points:  []Point{{7, -8}, {9, -10}},
+	}
+
+	generated := &bytes.Buffer{}
+	if err := h.WriteTo(endianio.NewLittleEndianWriter(generated)); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	// Encode only uses WriteTo for addressable values, so this uses reflection
+	reflected := &bytes.Buffer{}
+	if err := endianio.Encode(endianio.NewLittleEndianWriter(reflected), h); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(generated.Bytes(), reflected.Bytes()) {
+		t.Fatalf("WriteTo() got = % X, want % X", generated.Bytes(), reflected.Bytes())
+	}
+
+	var got Header
+	if err := got.ReadFrom(endianio.NewLittleEndianReader(generated)); err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, h) {
+		t.Errorf("ReadFrom() got = %+v, want %+v", got, h)
+	}
+}
+
+// TestGeneratedOverrideOffset checks that a byte order override reports the
+// offset of the field it failed on.
+func TestGeneratedOverrideOffset(t *testing.T) {
+	// Ratio, a little-endian override, starts at offset 19
+	r := endianio.NewBigEndianReader(bytes.NewReader(make([]byte, 21)))
+	var h Header
+	err := h.ReadFrom(r)
+	var oe *endianio.OffsetError
+	if !errors.As(err, &oe) {
+		t.Fatalf("ReadFrom() error = %v, want an OffsetError", err)
+	}
+	if oe.Offset != 19 {
+		t.Errorf("ReadFrom() error offset = %d, want 19", oe.Offset)
+	}
+}
+
+// TestGeneratedInvalidLength checks that a negative length field fails, as it
+// does with Decode.
+func TestGeneratedInvalidLength(t *testing.T) {
+	var got Samples
+	if err := got.ReadFrom(endianio.NewBigEndianReader(bytes.NewReader([]byte{0xff}))); !errors.Is(err, endianio.ErrInvalidLength) {
+		t.Errorf("ReadFrom() error = %v, want %v", err, endianio.ErrInvalidLength)
+	}
+}
+
+// TestGeneratedLimits checks that a length past the limits of the reader fails
+// before the slice is read, as it does with Decode.
+func TestGeneratedLimits(t *testing.T) {
+	r := endianio.NewBigEndianReader(bytes.NewReader(append([]byte{100}, make([]byte, 100)...)))
+	r.SetLimits(endianio.Limits{Budget: 10})
+	var got Samples
+	if err := got.ReadFrom(r); !errors.Is(err, endianio.ErrLimitExceeded) {
+		t.Errorf("ReadFrom() error = %v, want %v", err, endianio.ErrLimitExceeded)
+	}
+	if r.Offset() != 1 {
+		t.Errorf("Offset() got = %d, want 1", r.Offset())
+	}
+}
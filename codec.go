@@ -0,0 +1,554 @@
+package endianio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedType is returned by Decode and Encode for values they cannot handle,
+	// such as int, uint, maps, strings or slices without a length field.
+	ErrUnsupportedType = errors.New("endianio: unsupported type")
+	// ErrLengthMismatch is returned when encoding a slice whose length does not
+	// match its length field.
+	ErrLengthMismatch = errors.New("endianio: slice length does not match length field")
+	// ErrInvalidLength is returned when decoding a slice whose length field is
+	// negative or too large for an int.
+	ErrInvalidLength = errors.New("endianio: invalid slice length")
+)
+
+// Unmarshaler is implemented by types that can read themselves from an EndianReader,
+// such as the methods generated by cmd/endiangen. Decode uses it when available.
+type Unmarshaler interface {
+	ReadFrom(r EndianReader) error
+}
+
+// Marshaler is implemented by types that can write themselves to an EndianWriter,
+// such as the methods generated by cmd/endiangen. Encode uses it when available.
+type Marshaler interface {
+	WriteTo(w EndianWriter) error
+}
+
+// Decode reads binary data from r into v, which must be a non-nil pointer to a
+// fixed-size value or a struct.
+//
+// Struct fields are read in declaration order. Supported field types are bool
+// (one byte), the sized integer types, float32, float64, arrays, nested structs
+// and slices whose length is given by an earlier integer field. Unexported
+// fields are ignored, and blank (_) fields are read and discarded as padding.
+//
+// Fields can be annotated with an endian struct tag, holding a comma separated
+// list of:
+//
+//	skip       the field is ignored
+//	len=Count  the field is a slice with as many elements as the earlier field Count
+//	big        the field, and anything nested in it, is read in big-endian format
+//	little     the field, and anything nested in it, is read in little-endian format
+//
+// Byte order overrides require r to also implement io.Reader, which the
+// readers in this package do.
+//
+// For example:
+//
+//	type Header struct {
+//		Magic   uint32 `endian:"big"`
+//		Version uint16
+//		_       [2]byte
+//		Count   uint16
+//		Offsets []uint32 `endian:"len=Count"`
+//		Cache   []byte   `endian:"skip"`
+//	}
+func Decode(r EndianReader, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: Decode requires a non-nil pointer, got %T", ErrUnsupportedType, v)
+	}
+	return decodeValue(r, rv.Elem())
+}
+
+// Encode writes v to w as binary data. v can be a value or a pointer to a value;
+// the supported types and struct tags are the ones documented for Decode.
+// Encode returns an error if a slice field's length does not match its length field.
+func Encode(w EndianWriter, v any) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return fmt.Errorf("%w: Encode requires a non-nil value", ErrUnsupportedType)
+	}
+	return encodeValue(w, rv)
+}
+
+// fieldTag holds the parsed endian struct tag of a field.
+type fieldTag struct {
+	skip  bool
+	len   string
+	order string
+}
+
+func parseFieldTag(f reflect.StructField) (fieldTag, error) {
+	var tag fieldTag
+	s, ok := f.Tag.Lookup("endian")
+	if !ok {
+		return tag, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		switch {
+		case opt == "skip":
+			tag.skip = true
+		case opt == "big" || opt == "little":
+			tag.order = opt
+		case strings.HasPrefix(opt, "len="):
+			tag.len = strings.TrimPrefix(opt, "len=")
+		case opt == "":
+		default:
+			return tag, fmt.Errorf("endianio: field %s: unknown endian tag option %q", f.Name, opt)
+		}
+	}
+	return tag, nil
+}
+
+// AsBigEndianReader returns r if it is a *BigEndianReader, or otherwise a new
+// BigEndianReader reading from the same stream, which requires r to implement io.Reader.
+func AsBigEndianReader(r EndianReader) (*BigEndianReader, error) {
+	if br, ok := r.(*BigEndianReader); ok {
+		return br, nil
+	}
+	rr, ok := r.(io.Reader)
+	if !ok {
+		return nil, fmt.Errorf("%w: byte order override requires an io.Reader, got %T", ErrUnsupportedType, r)
+	}
+	nr := NewBigEndianReader(rr)
+	nr.layer(r)
+	return nr, nil
+}
+
+// AsLittleEndianReader returns r if it is a *LittleEndianReader, or otherwise a new
+// LittleEndianReader reading from the same stream, which requires r to implement io.Reader.
+func AsLittleEndianReader(r EndianReader) (*LittleEndianReader, error) {
+	if lr, ok := r.(*LittleEndianReader); ok {
+		return lr, nil
+	}
+	rr, ok := r.(io.Reader)
+	if !ok {
+		return nil, fmt.Errorf("%w: byte order override requires an io.Reader, got %T", ErrUnsupportedType, r)
+	}
+	nr := NewLittleEndianReader(rr)
+	nr.layer(r)
+	return nr, nil
+}
+
+// AsBigEndianWriter returns w if it is a *BigEndianWriter, or otherwise a new
+// BigEndianWriter writing to the same stream, which requires w to implement io.Writer.
+func AsBigEndianWriter(w EndianWriter) (*BigEndianWriter, error) {
+	if bw, ok := w.(*BigEndianWriter); ok {
+		return bw, nil
+	}
+	ww, ok := w.(io.Writer)
+	if !ok {
+		return nil, fmt.Errorf("%w: byte order override requires an io.Writer, got %T", ErrUnsupportedType, w)
+	}
+	nr := NewBigEndianWriter(ww)
+	nr.off = streamOffset(w)
+	return nr, nil
+}
+
+// AsLittleEndianWriter returns w if it is a *LittleEndianWriter, or otherwise a new
+// LittleEndianWriter writing to the same stream, which requires w to implement io.Writer.
+func AsLittleEndianWriter(w EndianWriter) (*LittleEndianWriter, error) {
+	if lw, ok := w.(*LittleEndianWriter); ok {
+		return lw, nil
+	}
+	ww, ok := w.(io.Writer)
+	if !ok {
+		return nil, fmt.Errorf("%w: byte order override requires an io.Writer, got %T", ErrUnsupportedType, w)
+	}
+	nr := NewLittleEndianWriter(ww)
+	nr.off = streamOffset(w)
+	return nr, nil
+}
+
+// offsetter is implemented by the readers and writers in this package.
+type offsetter interface {
+	Offset() int64
+}
+
+// streamOffset returns the offset of v if it is one of the readers or writers in
+// this package, so a reader or writer layered on top of it reports the same offsets.
+func streamOffset(v any) int64 {
+	if o, ok := v.(offsetter); ok {
+		return o.Offset()
+	}
+	return 0
+}
+
+// layer sets the offset and limits of r, a reader on top of the stream of
+// under, to those of under.
+func (r *baseReader) layer(under EndianReader) {
+	r.off = streamOffset(under)
+	if lr, ok := under.(limitedReader); ok {
+		r.limits, r.end = lr.base().limits, lr.base().end
+	}
+}
+
+// orderedReader returns a reader for the byte order named by order, reading
+// from the same stream as r.
+func orderedReader(r EndianReader, order string) (EndianReader, error) {
+	if order == "big" {
+		return AsBigEndianReader(r)
+	}
+	return AsLittleEndianReader(r)
+}
+
+// orderedWriter returns a writer for the byte order named by order, writing
+// to the same stream as w.
+func orderedWriter(w EndianWriter, order string) (EndianWriter, error) {
+	if order == "big" {
+		return AsBigEndianWriter(w)
+	}
+	return AsLittleEndianWriter(w)
+}
+
+// lengthField returns the value of the integer field named name in the struct v,
+// which must come before the field at index i.
+func lengthField(v reflect.Value, i int, name string) (int, error) {
+	f, ok := v.Type().FieldByName(name)
+	if !ok || len(f.Index) != 1 || f.Index[0] >= i {
+		return 0, fmt.Errorf("endianio: field %s: length field %s must be an earlier field", v.Type().Field(i).Name, name)
+	}
+	lv := v.Field(f.Index[0])
+	switch lv.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if lv.Uint() > math.MaxInt {
+			return 0, fmt.Errorf("%w: field %s: length %d in %s is too large", ErrInvalidLength, v.Type().Field(i).Name, lv.Uint(), name)
+		}
+		return int(lv.Uint()), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if lv.Int() < 0 {
+			return 0, fmt.Errorf("%w: field %s: negative length %d in %s", ErrInvalidLength, v.Type().Field(i).Name, lv.Int(), name)
+		}
+		if lv.Int() > math.MaxInt {
+			return 0, fmt.Errorf("%w: field %s: length %d in %s is too large", ErrInvalidLength, v.Type().Field(i).Name, lv.Int(), name)
+		}
+		return int(lv.Int()), nil
+	}
+	return 0, fmt.Errorf("%w: length field %s is %s", ErrUnsupportedType, name, lv.Type())
+}
+
+func decodeValue(r EndianReader, v reflect.Value) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(Unmarshaler); ok {
+			return u.ReadFrom(r)
+		}
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		var x uint8
+		x, err = r.ReadUint8()
+		v.SetBool(x != 0)
+	case reflect.Uint8:
+		var x uint8
+		x, err = r.ReadUint8()
+		v.SetUint(uint64(x))
+	case reflect.Uint16:
+		var x uint16
+		x, err = r.ReadUint16()
+		v.SetUint(uint64(x))
+	case reflect.Uint32:
+		var x uint32
+		x, err = r.ReadUint32()
+		v.SetUint(uint64(x))
+	case reflect.Uint64:
+		var x uint64
+		x, err = r.ReadUint64()
+		v.SetUint(x)
+	case reflect.Int8:
+		var x int8
+		x, err = r.ReadInt8()
+		v.SetInt(int64(x))
+	case reflect.Int16:
+		var x int16
+		x, err = r.ReadInt16()
+		v.SetInt(int64(x))
+	case reflect.Int32:
+		var x int32
+		x, err = r.ReadInt32()
+		v.SetInt(int64(x))
+	case reflect.Int64:
+		var x int64
+		x, err = r.ReadInt64()
+		v.SetInt(x)
+	case reflect.Float32:
+		var x float32
+		x, err = r.ReadFloat32()
+		v.SetFloat(float64(x))
+	case reflect.Float64:
+		var x float64
+		x, err = r.ReadFloat64()
+		v.SetFloat(x)
+	case reflect.Array:
+		for i := 0; i < v.Len() && err == nil; i++ {
+			err = decodeValue(r, v.Index(i))
+		}
+	case reflect.Struct:
+		err = decodeStruct(r, v)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+	return err
+}
+
+func decodeStruct(r EndianReader, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, err := parseFieldTag(f)
+		if err != nil {
+			return err
+		}
+		if tag.skip || (!f.IsExported() && f.Name != "_") {
+			continue
+		}
+		fr := r
+		if tag.order != "" {
+			if fr, err = orderedReader(r, tag.order); err != nil {
+				return err
+			}
+		}
+		fv := v.Field(i)
+		if f.Name == "_" {
+			// Blank fields are padding; decode into a scratch value
+			fv = reflect.New(f.Type).Elem()
+		}
+		if fv.Kind() == reflect.Slice {
+			if tag.len == "" {
+				return fmt.Errorf("%w: slice field %s needs a len tag", ErrUnsupportedType, f.Name)
+			}
+			n, err := lengthField(v, i, tag.len)
+			if err != nil {
+				return err
+			}
+			if err := checkSlice(r, "Decode", n, f.Type.Elem()); err != nil {
+				return err
+			}
+			s, err := decodeSlice(fr, f.Type, n)
+			if err != nil {
+				return err
+			}
+			fv.Set(s)
+			continue
+		}
+		if err := decodeValue(fr, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lengthInt is the set of types usable as slice length fields.
+type lengthInt interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// MakeSlice returns an empty slice to append the n elements of a slice field to
+// as they are read from r, where n is the value of its length field. It fails
+// with ErrInvalidLength if n is negative or does not fit in an int, and, like
+// Decode, with ErrLimitExceeded if the slice would go past the limits of r set
+// by SetLimits. It does not allocate room for more than the first elements, so
+// a hostile length cannot force a large allocation without supplying the data.
+//
+// MakeSlice is used by the ReadFrom methods generated by cmd/endiangen.
+func MakeSlice[E any, N lengthInt](r EndianReader, n N) ([]E, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: negative length %d", ErrInvalidLength, n)
+	}
+	if uint64(n) > math.MaxInt {
+		return nil, fmt.Errorf("%w: length %d is too large", ErrInvalidLength, n)
+	}
+	elem := reflect.TypeFor[E]()
+	if err := checkSlice(r, "MakeSlice", int(n), elem); err != nil {
+		return nil, err
+	}
+	return make([]E, 0, sliceCap(int(n), elem)), nil
+}
+
+// checkSlice fails for the method op if r is a reader of this package and a
+// slice of n elements of type elem would allocate more than its MaxAlloc, or
+// read past its budget. Elements that read themselves with a ReadFrom method
+// are only checked against MaxAlloc, as their size in the input is not known.
+func checkSlice(r EndianReader, op string, n int, elem reflect.Type) error {
+	lr, ok := r.(limitedReader)
+	if !ok {
+		return nil
+	}
+	br := lr.base()
+	if br.limits == (Limits{}) {
+		return nil
+	}
+	if err := br.checkAlloc(op, br.off, n, max(1, int(elem.Size()))); err != nil {
+		return err
+	}
+	if size := wireSize(elem); size > 0 {
+		return br.checkRemaining(op, br.off, n, size)
+	}
+	return nil
+}
+
+// wireSize returns the number of bytes Decode reads for a value of type t,
+// not counting slices, or 0 if it is not known.
+func wireSize(t reflect.Type) int {
+	if reflect.PointerTo(t).Implements(reflect.TypeFor[Unmarshaler]()) {
+		return 0
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Int8:
+		return 1
+	case reflect.Uint16, reflect.Int16:
+		return 2
+	case reflect.Uint32, reflect.Int32, reflect.Float32:
+		return 4
+	case reflect.Uint64, reflect.Int64, reflect.Float64:
+		return 8
+	case reflect.Array:
+		return t.Len() * wireSize(t.Elem())
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, err := parseFieldTag(f)
+			if err != nil {
+				return 0
+			}
+			if tag.skip || (!f.IsExported() && f.Name != "_") || f.Type.Kind() == reflect.Slice {
+				continue
+			}
+			n := wireSize(f.Type)
+			if n == 0 {
+				return 0
+			}
+			size += n
+		}
+		return size
+	}
+	return 0
+}
+
+// sliceCap returns the capacity to allocate for a decoded slice of n elements of
+// type elem.
+func sliceCap(n int, elem reflect.Type) int {
+	return min(n, prefixedChunk/max(1, int(elem.Size())))
+}
+
+// decodeSlice reads a slice of type t with n elements. The length comes from
+// the input, so the slice grows as elements arrive rather than being allocated
+// up front.
+func decodeSlice(r EndianReader, t reflect.Type, n int) (reflect.Value, error) {
+	elem := t.Elem()
+	s := reflect.MakeSlice(t, 0, sliceCap(n, elem))
+	for j := 0; j < n; j++ {
+		s = reflect.Append(s, reflect.Zero(elem))
+		if err := decodeValue(r, s.Index(j)); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+func encodeValue(w EndianWriter, v reflect.Value) error {
+	if m, ok := v.Interface().(Marshaler); ok {
+		return m.WriteTo(w)
+	}
+	if v.CanAddr() {
+		if m, ok := v.Addr().Interface().(Marshaler); ok {
+			return m.WriteTo(w)
+		}
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		var x uint8
+		if v.Bool() {
+			x = 1
+		}
+		_, err = w.WriteUint8(x)
+	case reflect.Uint8:
+		_, err = w.WriteUint8(uint8(v.Uint()))
+	case reflect.Uint16:
+		_, err = w.WriteUint16(uint16(v.Uint()))
+	case reflect.Uint32:
+		_, err = w.WriteUint32(uint32(v.Uint()))
+	case reflect.Uint64:
+		_, err = w.WriteUint64(v.Uint())
+	case reflect.Int8:
+		_, err = w.WriteInt8(int8(v.Int()))
+	case reflect.Int16:
+		_, err = w.WriteInt16(int16(v.Int()))
+	case reflect.Int32:
+		_, err = w.WriteInt32(int32(v.Int()))
+	case reflect.Int64:
+		_, err = w.WriteInt64(v.Int())
+	case reflect.Float32:
+		_, err = w.WriteFloat32(float32(v.Float()))
+	case reflect.Float64:
+		_, err = w.WriteFloat64(v.Float())
+	case reflect.Array:
+		for i := 0; i < v.Len() && err == nil; i++ {
+			err = encodeValue(w, v.Index(i))
+		}
+	case reflect.Struct:
+		err = encodeStruct(w, v)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+	return err
+}
+
+func encodeStruct(w EndianWriter, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, err := parseFieldTag(f)
+		if err != nil {
+			return err
+		}
+		if tag.skip || (!f.IsExported() && f.Name != "_") {
+			continue
+		}
+		fw := w
+		if tag.order != "" {
+			if fw, err = orderedWriter(w, tag.order); err != nil {
+				return err
+			}
+		}
+		fv := v.Field(i)
+		if f.Name == "_" {
+			// Blank fields are padding and always written as zeros
+			fv = reflect.New(f.Type).Elem()
+		}
+		if fv.Kind() == reflect.Slice {
+			if tag.len == "" {
+				return fmt.Errorf("%w: slice field %s needs a len tag", ErrUnsupportedType, f.Name)
+			}
+			n, err := lengthField(v, i, tag.len)
+			if err != nil {
+				return err
+			}
+			if n != fv.Len() {
+				return fmt.Errorf("%w: field %s has %d elements, but %s is %d", ErrLengthMismatch, f.Name, fv.Len(), tag.len, n)
+			}
+			for j := 0; j < n; j++ {
+				if err := encodeValue(fw, fv.Index(j)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := encodeValue(fw, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type codecPoint struct {
+	X, Y int16
+}
+
+type codecHeader struct {
+	Magic   uint32 `endian:"big"`
+	Version uint16
+	Flags   bool
+	_       [1]byte
+	Scale   float32
+	Origin  codecPoint
+	Corners [2]codecPoint `endian:"big"`
+	Count   uint8
+	Offsets []uint32 `endian:"len=Count"`
+	Cache   []byte   `endian:"skip"`
+	private uint64
+}
+
+var codecHeaderData = []byte{
+	0xCA, 0xFE, 0xBA, 0xBE, // Magic, big-endian
+	0x02, 0x01, // Version
+	0x01,                   // Flags
+	0x00,                   // padding
+	0x00, 0x00, 0xC0, 0x3F, // Scale 1.5
+	0xFF, 0xFF, 0x02, 0x00, // Origin {-1, 2}
+	0x00, 0x03, 0xFF, 0xFC, 0x00, 0x05, 0xFF, 0xFA, // Corners, big-endian {3, -4} {5, -6}
+	0x02,                   // Count
+	0x78, 0x56, 0x34, 0x12, // Offsets[0]
+	0xF0, 0xDE, 0xBC, 0x9A, // Offsets[1]
+}
+
+var codecHeaderValue = codecHeader{
+	Magic:   0xCAFEBABE,
+	Version: 0x0102,
+	Flags:   true,
+	Scale:   1.5,
+	Origin:  codecPoint{-1, 2},
+	Corners: [2]codecPoint{{3, -4}, {5, -6}},
+	Count:   2,
+	Offsets: []uint32{0x12345678, 0x9ABCDEF0},
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("MixedEndianHeader", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(codecHeaderData))
+		var got codecHeader
+		if err := Decode(r, &got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, codecHeaderValue) {
+			t.Errorf("Decode() got = %+v, want %+v", got, codecHeaderValue)
+		}
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		r := NewBigEndianReader(bytes.NewReader([]byte{0x12, 0x34, 0x56, 0x78}))
+		var got [2]uint16
+		if err := Decode(r, &got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if want := [2]uint16{0x1234, 0x5678}; got != want {
+			t.Errorf("Decode() got = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ShortInput", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(codecHeaderData[:len(codecHeaderData)-2]))
+		var got codecHeader
+		if err := Decode(r, &got); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Decode() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+
+	t.Run("ErrorCases", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			v    any
+		}{
+			{"NotPointer", codecPoint{}},
+			{"NilPointer", (*codecPoint)(nil)},
+			{"Int", new(int)},
+			{"String", new(string)},
+			{"SliceWithoutLen", new(struct{ S []uint8 })},
+			{"LenAfterSlice", new(struct {
+				S []uint8 `endian:"len=N"`
+				N uint8
+			})},
+			{"LenNotInteger", new(struct {
+				N float32
+				S []uint8 `endian:"len=N"`
+			})},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(make([]byte, 16)))
+				if err := Decode(r, tt.v); err == nil {
+					t.Errorf("Decode() expected error for %T", tt.v)
+				}
+			})
+		}
+
+		r := NewBigEndianReader(bytes.NewReader(make([]byte, 16)))
+		err := Decode(r, new(struct {
+			A uint8 `endian:"middle"`
+		}))
+		if err == nil {
+			t.Errorf("Decode() expected error for unknown tag option")
+		}
+	})
+
+	t.Run("InvalidLength", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input []byte
+			v     any
+		}{
+			{"Negative", []byte{0xff}, new(struct {
+				N int8
+				S []uint8 `endian:"len=N"`
+			})},
+			{"TooLarge", []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, new(struct {
+				Count uint64
+				Data  []byte `endian:"len=Count"`
+			})},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.input))
+				if err := Decode(r, tt.v); !errors.Is(err, ErrInvalidLength) {
+					t.Errorf("Decode() error = %v, want %v", err, ErrInvalidLength)
+				}
+			})
+		}
+	})
+
+	t.Run("SignedTooLargeForInt", func(t *testing.T) {
+		// 2^32+2 only fits in an int on 64-bit platforms, where the data runs out
+		type samples struct {
+			N int64
+			S []uint8 `endian:"len=N"`
+		}
+		want := io.EOF
+		if strconv.IntSize == 32 {
+			want = ErrInvalidLength
+		}
+		r := NewBigEndianReader(bytes.NewReader([]byte{0, 0, 0, 1, 0, 0, 0, 2, 1, 2}))
+		if err := Decode(r, &samples{}); !errors.Is(err, want) {
+			t.Errorf("Decode() error = %v, want %v", err, want)
+		}
+		if strconv.IntSize == 32 {
+			v := samples{N: 1<<32 + 2, S: []uint8{1, 2}}
+			if err := Encode(NewBigEndianWriter(&bytes.Buffer{}), &v); !errors.Is(err, ErrInvalidLength) {
+				t.Errorf("Encode() error = %v, want %v", err, ErrInvalidLength)
+			}
+		}
+	})
+
+	t.Run("LengthBeyondInput", func(t *testing.T) {
+		// The slice must not be allocated up front from the untrusted count
+		var v struct {
+			Count uint32
+			Data  []uint64 `endian:"len=Count"`
+		}
+		r := NewBigEndianReader(bytes.NewReader([]byte{0x0f, 0xff, 0xff, 0xff, 1, 2}))
+		if err := Decode(r, &v); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Decode() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestMakeSlice(t *testing.T) {
+	r := NewBigEndianReader(bytes.NewReader(nil))
+	if _, err := MakeSlice[uint32](r, int8(-1)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("MakeSlice(-1) error = %v, want %v", err, ErrInvalidLength)
+	}
+	if _, err := MakeSlice[uint32](r, uint64(1)<<63); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("MakeSlice(1<<63) error = %v, want %v", err, ErrInvalidLength)
+	}
+	s, err := MakeSlice[uint32](r, uint32(0x7fffffff))
+	if err != nil {
+		t.Fatalf("MakeSlice(0x7fffffff) error = %v", err)
+	}
+	if len(s) != 0 || cap(s) > prefixedChunk/4 {
+		t.Errorf("MakeSlice(0x7fffffff) got len %d cap %d, want len 0 cap <= %d", len(s), cap(s), prefixedChunk/4)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("MixedEndianHeader", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		w := NewLittleEndianWriter(buf)
+		v := codecHeaderValue
+		v.Cache = []byte{1, 2, 3}
+		if err := Encode(w, &v); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if got := buf.Bytes(); !bytes.Equal(got, codecHeaderData) {
+			t.Errorf("Encode() got = % X, want % X", got, codecHeaderData)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := Encode(NewBigEndianWriter(buf), codecHeaderValue); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		var got codecHeader
+		if err := Decode(NewBigEndianReader(buf), &got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, codecHeaderValue) {
+			t.Errorf("Decode() got = %+v, want %+v", got, codecHeaderValue)
+		}
+	})
+
+	t.Run("LengthMismatch", func(t *testing.T) {
+		v := codecHeaderValue
+		v.Count = 3
+		if err := Encode(NewBigEndianWriter(&bytes.Buffer{}), v); !errors.Is(err, ErrLengthMismatch) {
+			t.Errorf("Encode() error = %v, want %v", err, ErrLengthMismatch)
+		}
+	})
+
+	t.Run("FailingWriter", func(t *testing.T) {
+		if err := Encode(NewBigEndianWriter(&failingWriter{}), codecHeaderValue); err == nil {
+			t.Errorf("Encode() expected error for failing writer")
+		}
+	})
+}
+
+// codecCustom implements Unmarshaler and Marshaler, storing its value as a single byte.
+type codecCustom struct {
+	V uint32
+}
+
+func (c *codecCustom) ReadFrom(r EndianReader) error {
+	b, err := r.ReadUint8()
+	c.V = uint32(b)
+	return err
+}
+
+func (c *codecCustom) WriteTo(w EndianWriter) error {
+	_, err := w.WriteUint8(uint8(c.V))
+	return err
+}
+
+func TestCodecMarshaler(t *testing.T) {
+	v := struct {
+		A codecCustom
+		B [2]codecCustom
+	}{codecCustom{1}, [2]codecCustom{{2}, {3}}}
+
+	buf := &bytes.Buffer{}
+	if err := Encode(NewBigEndianWriter(buf), &v); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := []byte{1, 2, 3}; !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Encode() got = % X, want % X", buf.Bytes(), want)
+	}
+
+	got := v
+	got.A.V, got.B[0].V, got.B[1].V = 0, 0, 0
+	if err := Decode(NewBigEndianReader(buf), &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got != v {
+		t.Errorf("Decode() got = %+v, want %+v", got, v)
+	}
+}
+
+func TestDecodeOffsetError(t *testing.T) {
+	t.Run("Reader", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(codecHeaderData[:18]))
+		var got codecHeader
+		err := Decode(r, &got)
+		var oe *OffsetError
+		if !errors.As(err, &oe) {
+			t.Fatalf("Decode() error = %v, want an *OffsetError", err)
+		}
+		// The failing field is the second big-endian corner coordinate
+		if oe.Op != "ReadInt16" || oe.Offset != 18 {
+			t.Errorf("Decode() error = %v, want ReadInt16 at offset 18", err)
+		}
+	})
+
+	t.Run("Decoder", func(t *testing.T) {
+		// A byte order override over a decoder keeps its offsets
+		d := NewLittleEndianDecoder([]byte{1, 2})
+		err := Decode(d, new(struct {
+			A uint8
+			B uint32 `endian:"big"`
+		}))
+		var oe *OffsetError
+		if !errors.As(err, &oe) || oe.Offset != 1 {
+			t.Errorf("Decode() error = %v, want an *OffsetError at offset 1", err)
+		}
+	})
+}
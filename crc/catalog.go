@@ -0,0 +1,88 @@
+package crc
+
+// Presets from the CRC RevEng catalogue, each verified against its check value.
+var (
+	CRC3GSM         = Params{Name: "CRC-3/GSM", Width: 3, Poly: 0x3, Init: 0x0, RefIn: false, RefOut: false, XorOut: 0x7, Check: 0x4}
+	CRC4G704        = Params{Name: "CRC-4/G-704", Width: 4, Poly: 0x3, Init: 0x0, RefIn: true, RefOut: true, XorOut: 0x0, Check: 0x7}
+	CRC5USB         = Params{Name: "CRC-5/USB", Width: 5, Poly: 0x05, Init: 0x1f, RefIn: true, RefOut: true, XorOut: 0x1f, Check: 0x19} // USB token packets
+	CRC7MMC         = Params{Name: "CRC-7/MMC", Width: 7, Poly: 0x09, Init: 0x00, RefIn: false, RefOut: false, XorOut: 0x00, Check: 0x75}
+	CRC8SMBus       = Params{Name: "CRC-8/SMBUS", Width: 8, Poly: 0x07, Init: 0x00, RefIn: false, RefOut: false, XorOut: 0x00, Check: 0xf4} // SMBus PEC
+	CRC8Autosar     = Params{Name: "CRC-8/AUTOSAR", Width: 8, Poly: 0x2f, Init: 0xff, RefIn: false, RefOut: false, XorOut: 0xff, Check: 0xdf}
+	CRC8Bluetooth   = Params{Name: "CRC-8/BLUETOOTH", Width: 8, Poly: 0xa7, Init: 0x00, RefIn: true, RefOut: true, XorOut: 0x00, Check: 0x26}
+	CRC8CDMA2000    = Params{Name: "CRC-8/CDMA2000", Width: 8, Poly: 0x9b, Init: 0xff, RefIn: false, RefOut: false, XorOut: 0x00, Check: 0xda}
+	CRC8I4321       = Params{Name: "CRC-8/I-432-1", Width: 8, Poly: 0x07, Init: 0x00, RefIn: false, RefOut: false, XorOut: 0x55, Check: 0xa1}
+	CRC8MaximDow    = Params{Name: "CRC-8/MAXIM-DOW", Width: 8, Poly: 0x31, Init: 0x00, RefIn: true, RefOut: true, XorOut: 0x00, Check: 0xa1} // Dallas/Maxim 1-Wire
+	CRC8ROHC        = Params{Name: "CRC-8/ROHC", Width: 8, Poly: 0x07, Init: 0xff, RefIn: true, RefOut: true, XorOut: 0x00, Check: 0xd0}
+	CRC8SAEJ1850    = Params{Name: "CRC-8/SAE-J1850", Width: 8, Poly: 0x1d, Init: 0xff, RefIn: false, RefOut: false, XorOut: 0xff, Check: 0x4b}
+	CRC10ATM        = Params{Name: "CRC-10/ATM", Width: 10, Poly: 0x233, Init: 0x000, RefIn: false, RefOut: false, XorOut: 0x000, Check: 0x199}
+	CRC15CAN        = Params{Name: "CRC-15/CAN", Width: 15, Poly: 0x4599, Init: 0x0000, RefIn: false, RefOut: false, XorOut: 0x0000, Check: 0x059e} // CAN 2.0 frames
+	CRC16ARC        = Params{Name: "CRC-16/ARC", Width: 16, Poly: 0x8005, Init: 0x0000, RefIn: true, RefOut: true, XorOut: 0x0000, Check: 0xbb3d}
+	CRC16DNP        = Params{Name: "CRC-16/DNP", Width: 16, Poly: 0x3d65, Init: 0x0000, RefIn: true, RefOut: true, XorOut: 0xffff, Check: 0xea82}
+	CRC16Genibus    = Params{Name: "CRC-16/GENIBUS", Width: 16, Poly: 0x1021, Init: 0xffff, RefIn: false, RefOut: false, XorOut: 0xffff, Check: 0xd64e}
+	CRC16IBM3740    = Params{Name: "CRC-16/IBM-3740", Width: 16, Poly: 0x1021, Init: 0xffff, RefIn: false, RefOut: false, XorOut: 0x0000, Check: 0x29b1} // also known as CRC-16/CCITT-FALSE
+	CRC16IBMSDLC    = Params{Name: "CRC-16/IBM-SDLC", Width: 16, Poly: 0x1021, Init: 0xffff, RefIn: true, RefOut: true, XorOut: 0xffff, Check: 0x906e}   // also known as CRC-16/X-25
+	CRC16Kermit     = Params{Name: "CRC-16/KERMIT", Width: 16, Poly: 0x1021, Init: 0x0000, RefIn: true, RefOut: true, XorOut: 0x0000, Check: 0x2189}     // also known as CRC-16/CCITT
+	CRC16MaximDow   = Params{Name: "CRC-16/MAXIM-DOW", Width: 16, Poly: 0x8005, Init: 0x0000, RefIn: true, RefOut: true, XorOut: 0xffff, Check: 0x44c2}
+	CRC16MCRF4XX    = Params{Name: "CRC-16/MCRF4XX", Width: 16, Poly: 0x1021, Init: 0xffff, RefIn: true, RefOut: true, XorOut: 0x0000, Check: 0x6f91}
+	CRC16Modbus     = Params{Name: "CRC-16/MODBUS", Width: 16, Poly: 0x8005, Init: 0xffff, RefIn: true, RefOut: true, XorOut: 0x0000, Check: 0x4b37}        // Modbus RTU, sent least significant byte first
+	CRC16SPIFujitsu = Params{Name: "CRC-16/SPI-FUJITSU", Width: 16, Poly: 0x1021, Init: 0x1d0f, RefIn: false, RefOut: false, XorOut: 0x0000, Check: 0xe5cc} // also known as CRC-16/AUG-CCITT
+	CRC16UMTS       = Params{Name: "CRC-16/UMTS", Width: 16, Poly: 0x8005, Init: 0x0000, RefIn: false, RefOut: false, XorOut: 0x0000, Check: 0xfee8}        // also known as CRC-16/BUYPASS
+	CRC16USB        = Params{Name: "CRC-16/USB", Width: 16, Poly: 0x8005, Init: 0xffff, RefIn: true, RefOut: true, XorOut: 0xffff, Check: 0xb4c8}
+	CRC16XModem     = Params{Name: "CRC-16/XMODEM", Width: 16, Poly: 0x1021, Init: 0x0000, RefIn: false, RefOut: false, XorOut: 0x0000, Check: 0x31c3} // XMODEM and ZMODEM
+	CRC24OpenPGP    = Params{Name: "CRC-24/OPENPGP", Width: 24, Poly: 0x864cfb, Init: 0xb704ce, RefIn: false, RefOut: false, XorOut: 0x000000, Check: 0x21cf02}
+	CRC32BZip2      = Params{Name: "CRC-32/BZIP2", Width: 32, Poly: 0x04c11db7, Init: 0xffffffff, RefIn: false, RefOut: false, XorOut: 0xffffffff, Check: 0xfc891918}
+	CRC32ISCSI      = Params{Name: "CRC-32/ISCSI", Width: 32, Poly: 0x1edc6f41, Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff, Check: 0xe3069283}    // Castagnoli
+	CRC32ISOHDLC    = Params{Name: "CRC-32/ISO-HDLC", Width: 32, Poly: 0x04c11db7, Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff, Check: 0xcbf43926} // the CRC-32 of zip, PNG and Ethernet, as in hash/crc32
+	CRC32MPEG2      = Params{Name: "CRC-32/MPEG-2", Width: 32, Poly: 0x04c11db7, Init: 0xffffffff, RefIn: false, RefOut: false, XorOut: 0x00000000, Check: 0x0376e6e7}
+	CRC64ECMA182    = Params{Name: "CRC-64/ECMA-182", Width: 64, Poly: 0x42f0e1eba9ea3693, Init: 0x0000000000000000, RefIn: false, RefOut: false, XorOut: 0x0000000000000000, Check: 0x6c40df5f0b497347}
+	CRC64XZ         = Params{Name: "CRC-64/XZ", Width: 64, Poly: 0x42f0e1eba9ea3693, Init: 0xffffffffffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffffffffffff, Check: 0x995dc9bbdf1939fa} // as in hash/crc64 with the ECMA table
+)
+
+// Catalog lists the presets, by width.
+var Catalog = []Params{
+	CRC3GSM,
+	CRC4G704,
+	CRC5USB,
+	CRC7MMC,
+	CRC8SMBus,
+	CRC8Autosar,
+	CRC8Bluetooth,
+	CRC8CDMA2000,
+	CRC8I4321,
+	CRC8MaximDow,
+	CRC8ROHC,
+	CRC8SAEJ1850,
+	CRC10ATM,
+	CRC15CAN,
+	CRC16ARC,
+	CRC16DNP,
+	CRC16Genibus,
+	CRC16IBM3740,
+	CRC16IBMSDLC,
+	CRC16Kermit,
+	CRC16MaximDow,
+	CRC16MCRF4XX,
+	CRC16Modbus,
+	CRC16SPIFujitsu,
+	CRC16UMTS,
+	CRC16USB,
+	CRC16XModem,
+	CRC24OpenPGP,
+	CRC32BZip2,
+	CRC32ISCSI,
+	CRC32ISOHDLC,
+	CRC32MPEG2,
+	CRC64ECMA182,
+	CRC64XZ,
+}
+
+// Lookup returns the preset in Catalog with the given RevEng name, such as
+// "CRC-16/MODBUS".
+func Lookup(name string) (Params, bool) {
+	for _, p := range Catalog {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Params{}, false
+}
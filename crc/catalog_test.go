@@ -0,0 +1,27 @@
+package crc
+
+import "testing"
+
+func TestCatalog(t *testing.T) {
+	names := make(map[string]bool)
+	for _, p := range Catalog {
+		t.Run(p.Name, func(t *testing.T) {
+			if names[p.Name] {
+				t.Errorf("duplicate name %q", p.Name)
+			}
+			names[p.Name] = true
+			if got := Checksum([]byte("123456789"), MakeTable(p)); got != p.Check {
+				t.Errorf("Checksum(\"123456789\") got = %#x, want %#x", got, p.Check)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if p, ok := Lookup("CRC-16/MODBUS"); !ok || p != CRC16Modbus {
+		t.Errorf("Lookup(\"CRC-16/MODBUS\") got = %v, %v, want %v", p, ok, CRC16Modbus)
+	}
+	if _, ok := Lookup("CRC-16/NONE"); ok {
+		t.Errorf("Lookup(\"CRC-16/NONE\") found a preset")
+	}
+}
@@ -0,0 +1,165 @@
+// Package crc implements cyclic redundancy checks of any width from 1 to 64
+// bits, described by the parameters of the Rocksoft model as used by the CRC
+// RevEng catalogue: width, poly, init, refin, refout and xorout. Catalog holds
+// named presets for the CRCs of common embedded protocols, such as CRC-16/MODBUS
+// and CRC-8/MAXIM-DOW.
+//
+// The hashes implement hash.Hash, so they can be attached to an endianio reader
+// or writer with SetHash; their Sum is big-endian, and WriteChecksum lays 16, 32
+// and 64-bit CRCs out in the byte order of the writer.
+package crc
+
+import (
+	"fmt"
+	"hash"
+	"math/bits"
+)
+
+// Params describes a CRC in the Rocksoft model.
+type Params struct {
+	Name   string // name in the CRC RevEng catalogue, e.g. "CRC-16/MODBUS"
+	Width  int    // width in bits, from 1 to 64
+	Poly   uint64 // generator polynomial, without the leading x^Width term
+	Init   uint64 // initial register value
+	RefIn  bool   // whether input bytes are processed least significant bit first
+	RefOut bool   // whether the register is reflected before XorOut is applied
+	XorOut uint64 // value XORed into the final register
+	Check  uint64 // CRC of the ASCII string "123456789"
+}
+
+// Table is a precomputed table for computing a CRC a byte at a time.
+type Table struct {
+	params Params
+	shift  uint // 64-Width, for the register of unreflected CRCs kept in the top bits
+	table  [256]uint64
+}
+
+// MakeTable returns the Table for the CRC p. It panics if p.Width is not from 1
+// to 64 or if p.Poly, p.Init or p.XorOut do not fit in p.Width bits.
+func MakeTable(p Params) *Table {
+	if p.Width < 1 || p.Width > 64 {
+		panic(fmt.Sprintf("crc: invalid width %d", p.Width))
+	}
+	t := &Table{params: p, shift: uint(64 - p.Width)}
+	if (p.Poly|p.Init|p.XorOut)<<t.shift>>t.shift != p.Poly|p.Init|p.XorOut {
+		panic(fmt.Sprintf("crc: parameters of %s do not fit in %d bits", p.Name, p.Width))
+	}
+
+	if p.RefIn {
+		poly := reflect(p.Poly, p.Width)
+		for i := range t.table {
+			crc := uint64(i)
+			for range 8 {
+				if crc&1 != 0 {
+					crc = crc>>1 ^ poly
+				} else {
+					crc >>= 1
+				}
+			}
+			t.table[i] = crc
+		}
+		return t
+	}
+	poly := p.Poly << t.shift
+	for i := range t.table {
+		crc := uint64(i) << 56
+		for range 8 {
+			if crc&(1<<63) != 0 {
+				crc = crc<<1 ^ poly
+			} else {
+				crc <<= 1
+			}
+		}
+		t.table[i] = crc
+	}
+	return t
+}
+
+// Params returns the parameters t was made for.
+func (t *Table) Params() Params {
+	return t.params
+}
+
+// init returns the initial register value. Reflected CRCs keep the register
+// reflected in the low bits, the others keep it in the top bits.
+func (t *Table) init() uint64 {
+	if t.params.RefIn {
+		return reflect(t.params.Init, t.params.Width)
+	}
+	return t.params.Init << t.shift
+}
+
+func (t *Table) update(crc uint64, p []byte) uint64 {
+	if t.params.RefIn {
+		for _, b := range p {
+			crc = t.table[byte(crc)^b] ^ crc>>8
+		}
+		return crc
+	}
+	for _, b := range p {
+		crc = t.table[byte(crc>>56)^b] ^ crc<<8
+	}
+	return crc
+}
+
+// final returns the CRC for the register crc.
+func (t *Table) final(crc uint64) uint64 {
+	if !t.params.RefIn {
+		crc >>= t.shift
+	}
+	if t.params.RefIn != t.params.RefOut {
+		crc = reflect(crc, t.params.Width)
+	}
+	return crc ^ t.params.XorOut
+}
+
+// Checksum returns the CRC of data using the table t.
+func Checksum(data []byte, t *Table) uint64 {
+	return t.final(t.update(t.init(), data))
+}
+
+// digest is a running CRC.
+type digest struct {
+	t   *Table
+	crc uint64
+}
+
+// New creates a new hash.Hash64 computing the CRC using the table t. Its Sum
+// appends the CRC big-endian, in as many bytes as the width needs.
+func New(t *Table) hash.Hash64 {
+	return &digest{t: t, crc: t.init()}
+}
+
+func (d *digest) Size() int {
+	return (d.t.params.Width + 7) / 8
+}
+
+func (d *digest) BlockSize() int {
+	return 1
+}
+
+func (d *digest) Reset() {
+	d.crc = d.t.init()
+}
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	d.crc = d.t.update(d.crc, p)
+	return len(p), nil
+}
+
+func (d *digest) Sum64() uint64 {
+	return d.t.final(d.crc)
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	s := d.Sum64()
+	for i := d.Size() - 1; i >= 0; i-- {
+		in = append(in, byte(s>>(8*i)))
+	}
+	return in
+}
+
+// reflect reverses the low width bits of v.
+func reflect(v uint64, width int) uint64 {
+	return bits.Reverse64(v) >> (64 - width)
+}
@@ -0,0 +1,99 @@
+package crc
+
+import (
+	"bytes"
+	"hash/crc32"
+	"hash/crc64"
+	"testing"
+
+	"github.com/noselasd/endianio"
+)
+
+func TestHash(t *testing.T) {
+	data := []byte("The quick brown fox jumps over the lazy dog")
+
+	// Compare with the standard library implementations
+	if got, want := Checksum(data, MakeTable(CRC32ISOHDLC)), uint64(crc32.ChecksumIEEE(data)); got != want {
+		t.Errorf("CRC32ISOHDLC got = %#x, want %#x", got, want)
+	}
+	if got, want := Checksum(data, MakeTable(CRC32ISCSI)), uint64(crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))); got != want {
+		t.Errorf("CRC32ISCSI got = %#x, want %#x", got, want)
+	}
+	if got, want := Checksum(data, MakeTable(CRC64XZ)), crc64.Checksum(data, crc64.MakeTable(crc64.ECMA)); got != want {
+		t.Errorf("CRC64XZ got = %#x, want %#x", got, want)
+	}
+
+	// Writing in pieces gives the same CRC as Checksum
+	for _, p := range []Params{CRC3GSM, CRC5USB, CRC16XModem, CRC16Modbus, CRC24OpenPGP, CRC64ECMA182} {
+		t.Run(p.Name, func(t *testing.T) {
+			tab := MakeTable(p)
+			h := New(tab)
+			h.Write(data[:7])
+			h.Write(data[7:])
+			if got, want := h.Sum64(), Checksum(data, tab); got != want {
+				t.Errorf("Sum64() got = %#x, want %#x", got, want)
+			}
+			h.Reset()
+			h.Write([]byte("123456789"))
+			if got := h.Sum64(); got != p.Check {
+				t.Errorf("Sum64() after Reset() got = %#x, want %#x", got, p.Check)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	var tests = []struct {
+		p    Params
+		size int
+		want []byte
+	}{
+		{CRC5USB, 1, []byte{0x19}},
+		{CRC8MaximDow, 1, []byte{0xa1}},
+		{CRC10ATM, 2, []byte{0x01, 0x99}},
+		{CRC16Modbus, 2, []byte{0x4b, 0x37}},
+		{CRC24OpenPGP, 3, []byte{0x21, 0xcf, 0x02}},
+		{CRC32ISOHDLC, 4, []byte{0xcb, 0xf4, 0x39, 0x26}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.p.Name, func(t *testing.T) {
+			h := New(MakeTable(tt.p))
+			h.Write([]byte("123456789"))
+			if h.Size() != tt.size {
+				t.Errorf("Size() got = %d, want %d", h.Size(), tt.size)
+			}
+			if got := h.Sum([]byte{0xff}); !bytes.Equal(got, append([]byte{0xff}, tt.want...)) {
+				t.Errorf("Sum() got = %x, want ff%x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeTablePanics(t *testing.T) {
+	for _, p := range []Params{{Width: 0}, {Width: 65}, {Width: 8, Poly: 0x107}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MakeTable(%+v) did not panic", p)
+				}
+			}()
+			MakeTable(p)
+		}()
+	}
+}
+
+func TestModbusFrame(t *testing.T) {
+	// Read holding registers request, whose CRC is sent least significant byte first
+	want := []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a, 0xc5, 0xcd}
+
+	buf := &bytes.Buffer{}
+	w := endianio.NewLittleEndianWriter(buf)
+	w.SetHash(New(MakeTable(CRC16Modbus)))
+	w.Write(want[:6])
+	if _, err := w.WriteChecksum(); err != nil {
+		t.Fatalf("WriteChecksum() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("frame got = %x, want %x", buf.Bytes(), want)
+	}
+}
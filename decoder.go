@@ -0,0 +1,256 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// baseDecoder provides common functionality for both big-endian and little-endian decoders.
+type baseDecoder struct {
+	buf []byte
+	off int
+}
+
+// next returns the next n bytes on behalf of the method op, and advances past
+// them. If fewer than n bytes remain nothing is consumed, and an *OffsetError
+// wrapping io.EOF if no bytes remain or io.ErrUnexpectedEOF otherwise is returned.
+func (d *baseDecoder) next(op string, n int) ([]byte, error) {
+	if avail := len(d.buf) - d.off; avail < n {
+		err := io.ErrUnexpectedEOF
+		if avail == 0 {
+			err = io.EOF
+		}
+		return nil, &OffsetError{Op: op, Offset: int64(d.off), Width: n, N: avail, Err: err}
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// Read implements io.Reader, reading from the remaining bytes.
+func (d *baseDecoder) Read(p []byte) (n int, err error) {
+	if d.off == len(d.buf) {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	n = copy(p, d.buf[d.off:])
+	d.off += n
+	return n, nil
+}
+
+// Offset returns the number of bytes consumed so far.
+func (d *baseDecoder) Offset() int64 {
+	return int64(d.off)
+}
+
+// Remaining returns the number of bytes left to decode.
+func (d *baseDecoder) Remaining() int {
+	return len(d.buf) - d.off
+}
+
+// Bytes returns the next n bytes without copying them, and advances past them.
+// The returned slice aliases the decoder's buffer. n must not be negative.
+func (d *baseDecoder) Bytes(n int) ([]byte, error) {
+	return d.next("Bytes", n)
+}
+
+// Reset resets the decoder to decode from b.
+func (d *baseDecoder) Reset(b []byte) {
+	d.buf = b
+	d.off = 0
+}
+
+// ReadUint8 reads a uint8 (byte)
+func (d *baseDecoder) ReadUint8() (uint8, error) {
+	b, err := d.next("ReadUint8", 1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// ReadInt8 reads an int8
+func (d *baseDecoder) ReadInt8() (int8, error) {
+	b, err := d.next("ReadInt8", 1)
+	if err != nil {
+		return 0, err
+	}
+	return int8(b[0]), nil
+}
+
+// BigEndianDecoder decodes binary data in big-endian format from a byte slice.
+type BigEndianDecoder struct {
+	baseDecoder
+}
+
+// NewBigEndianDecoder creates a new BigEndianDecoder decoding from b.
+func NewBigEndianDecoder(b []byte) *BigEndianDecoder {
+	return &BigEndianDecoder{baseDecoder{buf: b}}
+}
+
+// Order returns binary.BigEndian.
+func (d *BigEndianDecoder) Order() binary.ByteOrder {
+	return binary.BigEndian
+}
+
+// ReadUint16 reads a 16-bit unsigned integer in big-endian format.
+func (d *BigEndianDecoder) ReadUint16() (uint16, error) {
+	b, err := d.next("ReadUint16", 2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+// ReadUint32 reads a 32-bit unsigned integer in big-endian format.
+func (d *BigEndianDecoder) ReadUint32() (uint32, error) {
+	b, err := d.next("ReadUint32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// ReadUint64 reads a 64-bit unsigned integer in big-endian format.
+func (d *BigEndianDecoder) ReadUint64() (uint64, error) {
+	b, err := d.next("ReadUint64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// ReadInt16 reads a 16-bit signed integer in big-endian format.
+func (d *BigEndianDecoder) ReadInt16() (int16, error) {
+	b, err := d.next("ReadInt16", 2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
+}
+
+// ReadInt32 reads a 32-bit signed integer in big-endian format.
+func (d *BigEndianDecoder) ReadInt32() (int32, error) {
+	b, err := d.next("ReadInt32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+// ReadInt64 reads a 64-bit signed integer in big-endian format.
+func (d *BigEndianDecoder) ReadInt64() (int64, error) {
+	b, err := d.next("ReadInt64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+// ReadFloat32 reads a 32-bit float encoded as a 32-bit unsigned integer in big-endian format.
+func (d *BigEndianDecoder) ReadFloat32() (float32, error) {
+	b, err := d.next("ReadFloat32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(binary.BigEndian.Uint32(b)), nil
+}
+
+// ReadFloat64 reads a 64-bit float encoded as a 64-bit unsigned integer in big-endian format.
+func (d *BigEndianDecoder) ReadFloat64() (float64, error) {
+	b, err := d.next("ReadFloat64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+}
+
+// LittleEndianDecoder decodes binary data in little-endian format from a byte slice.
+type LittleEndianDecoder struct {
+	baseDecoder
+}
+
+// NewLittleEndianDecoder creates a new LittleEndianDecoder decoding from b.
+func NewLittleEndianDecoder(b []byte) *LittleEndianDecoder {
+	return &LittleEndianDecoder{baseDecoder{buf: b}}
+}
+
+// Order returns binary.LittleEndian.
+func (d *LittleEndianDecoder) Order() binary.ByteOrder {
+	return binary.LittleEndian
+}
+
+// ReadUint16 reads a 16-bit unsigned integer in little-endian format.
+func (d *LittleEndianDecoder) ReadUint16() (uint16, error) {
+	b, err := d.next("ReadUint16", 2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+// ReadUint32 reads a 32-bit unsigned integer in little-endian format.
+func (d *LittleEndianDecoder) ReadUint32() (uint32, error) {
+	b, err := d.next("ReadUint32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// ReadUint64 reads a 64-bit unsigned integer in little-endian format.
+func (d *LittleEndianDecoder) ReadUint64() (uint64, error) {
+	b, err := d.next("ReadUint64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// ReadInt16 reads a 16-bit signed integer in little-endian format.
+func (d *LittleEndianDecoder) ReadInt16() (int16, error) {
+	b, err := d.next("ReadInt16", 2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.LittleEndian.Uint16(b)), nil
+}
+
+// ReadInt32 reads a 32-bit signed integer in little-endian format.
+func (d *LittleEndianDecoder) ReadInt32() (int32, error) {
+	b, err := d.next("ReadInt32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.LittleEndian.Uint32(b)), nil
+}
+
+// ReadInt64 reads a 64-bit signed integer in little-endian format.
+func (d *LittleEndianDecoder) ReadInt64() (int64, error) {
+	b, err := d.next("ReadInt64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(b)), nil
+}
+
+// ReadFloat32 reads a 32-bit float encoded as a 32-bit unsigned integer in little-endian format.
+func (d *LittleEndianDecoder) ReadFloat32() (float32, error) {
+	b, err := d.next("ReadFloat32", 4)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+}
+
+// ReadFloat64 reads a 64-bit float encoded as a 64-bit unsigned integer in little-endian format.
+func (d *LittleEndianDecoder) ReadFloat64() (float64, error) {
+	b, err := d.next("ReadFloat64", 8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+}
@@ -0,0 +1,174 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+// Compile time checks that the decoders satisfy EndianReader
+var (
+	_ EndianReader = (*BigEndianDecoder)(nil)
+	_ EndianReader = (*LittleEndianDecoder)(nil)
+)
+
+func TestBigEndianDecoder(t *testing.T) {
+	data := []byte{
+		0xA5,       // uint8
+		0x12, 0x34, // uint16
+		0x12, 0x34, 0x56, 0x78, // uint32
+		0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE, 0xF0, // uint64
+		0xFF, 0xFE, // int16
+		0x3d, 0xcc, 0xcc, 0xcd, // float32
+		0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a, // float64
+	}
+	d := NewBigEndianDecoder(data)
+
+	if got, err := d.ReadUint8(); err != nil || got != 0xA5 {
+		t.Errorf("ReadUint8() got = %v, %v, want 0xA5", got, err)
+	}
+	if got, err := d.ReadUint16(); err != nil || got != 0x1234 {
+		t.Errorf("ReadUint16() got = %v, %v, want 0x1234", got, err)
+	}
+	if got, err := d.ReadUint32(); err != nil || got != 0x12345678 {
+		t.Errorf("ReadUint32() got = %v, %v, want 0x12345678", got, err)
+	}
+	if got, err := d.ReadUint64(); err != nil || got != 0x123456789ABCDEF0 {
+		t.Errorf("ReadUint64() got = %v, %v, want 0x123456789ABCDEF0", got, err)
+	}
+	if got, err := d.ReadInt16(); err != nil || got != -2 {
+		t.Errorf("ReadInt16() got = %v, %v, want -2", got, err)
+	}
+	if got, err := d.ReadFloat32(); err != nil || got != 0.1 {
+		t.Errorf("ReadFloat32() got = %v, %v, want 0.1", got, err)
+	}
+	if got, err := d.ReadFloat64(); err != nil || got != 0.1 {
+		t.Errorf("ReadFloat64() got = %v, %v, want 0.1", got, err)
+	}
+	if d.Offset() != int64(len(data)) || d.Remaining() != 0 {
+		t.Errorf("Offset() = %d, Remaining() = %d, want %d, 0", d.Offset(), d.Remaining(), len(data))
+	}
+	if _, err := d.ReadUint8(); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadUint8() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestLittleEndianDecoder(t *testing.T) {
+	data := []byte{
+		0xA5,       // uint8
+		0x34, 0x12, // uint16
+		0x78, 0x56, 0x34, 0x12, // uint32
+		0xF0, 0xDE, 0xBC, 0x9A, 0x78, 0x56, 0x34, 0x12, // uint64
+		0xFE, 0xFF, 0xFF, 0xFF, // int32
+		0x00, 0x00, 0x80, 0xff, // float32
+		0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0xbf, // float64
+	}
+	d := NewLittleEndianDecoder(data)
+
+	if got, err := d.ReadInt8(); err != nil || got != -0x5B {
+		t.Errorf("ReadInt8() got = %v, %v, want -0x5B", got, err)
+	}
+	if got, err := d.ReadUint16(); err != nil || got != 0x1234 {
+		t.Errorf("ReadUint16() got = %v, %v, want 0x1234", got, err)
+	}
+	if got, err := d.ReadUint32(); err != nil || got != 0x12345678 {
+		t.Errorf("ReadUint32() got = %v, %v, want 0x12345678", got, err)
+	}
+	if got, err := d.ReadInt64(); err != nil || got != 0x123456789ABCDEF0 {
+		t.Errorf("ReadInt64() got = %v, %v, want 0x123456789ABCDEF0", got, err)
+	}
+	if got, err := d.ReadInt32(); err != nil || got != -2 {
+		t.Errorf("ReadInt32() got = %v, %v, want -2", got, err)
+	}
+	if got, err := d.ReadFloat32(); err != nil || got != float32(math.Inf(-1)) {
+		t.Errorf("ReadFloat32() got = %v, %v, want -Inf", got, err)
+	}
+	if got, err := d.ReadFloat64(); err != nil || got != -0.1 {
+		t.Errorf("ReadFloat64() got = %v, %v, want -0.1", got, err)
+	}
+	if d.Remaining() != 0 {
+		t.Errorf("Remaining() = %d, want 0", d.Remaining())
+	}
+}
+
+func TestDecoderBytes(t *testing.T) {
+	data := []byte{0x00, 0x03, 'a', 'b', 'c', 0x12, 0x34, 0x56}
+	d := NewBigEndianDecoder(data)
+
+	n, _ := d.ReadUint16()
+	got, err := d.Bytes(int(n))
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if string(got) != "abc" {
+		t.Errorf("Bytes() got = %q, want %q", got, "abc")
+	}
+	if &got[0] != &data[2] {
+		t.Errorf("Bytes() copied the data")
+	}
+
+	// A short read consumes nothing
+	if _, err := d.ReadUint32(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadUint32() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if d.Remaining() != 3 {
+		t.Errorf("Remaining() = %d, want 3", d.Remaining())
+	}
+	if _, err := d.Bytes(4); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Bytes() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	p := make([]byte, 8)
+	if n, err := d.Read(p); n != 3 || err != nil || !bytes.Equal(p[:n], data[5:]) {
+		t.Errorf("Read() got = %d, %v, % X", n, err, p[:n])
+	}
+	if _, err := d.Read(p); err != io.EOF {
+		t.Errorf("Read() error = %v, want %v", err, io.EOF)
+	}
+
+	d.Reset(data[5:])
+	if got, err := d.ReadUint16(); err != nil || got != 0x1234 {
+		t.Errorf("ReadUint16() after Reset() got = %v, %v, want 0x1234", got, err)
+	}
+}
+
+func BenchmarkBigEndianDecoder_ReadUint32(b *testing.B) {
+	d := NewBigEndianDecoder(bigEndianUint32Data)
+
+	for b.Loop() {
+		d.Reset(bigEndianUint32Data)
+
+		_, err := d.ReadUint32()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLittleEndianDecoder_ReadUint32(b *testing.B) {
+	d := NewLittleEndianDecoder(littleEndianUint32Data)
+
+	for b.Loop() {
+		d.Reset(littleEndianUint32Data)
+
+		_, err := d.ReadUint32()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLittleEndianDecoder_ReadUint64(b *testing.B) {
+	d := NewLittleEndianDecoder(littleEndianUint64Data)
+
+	for b.Loop() {
+		d.Reset(littleEndianUint64Data)
+
+		_, err := d.ReadUint64()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
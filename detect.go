@@ -0,0 +1,93 @@
+package endianio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnknownMagic is returned by DetectReader when the stream starts with
+// neither the big-endian nor the little-endian form of the magic value.
+var ErrUnknownMagic = errors.New("endianio: unknown magic value")
+
+// Magic describes how a format announces its byte order in its first bytes.
+// Big and Little must have the same length.
+type Magic struct {
+	Big    []byte // the leading bytes of a big-endian stream
+	Little []byte // the leading bytes of a little-endian stream
+}
+
+// MagicUint16 returns the Magic for a format starting with the 16-bit value v
+// written in its own byte order.
+func MagicUint16(v uint16) Magic {
+	return Magic{
+		Big:    binary.BigEndian.AppendUint16(nil, v),
+		Little: binary.LittleEndian.AppendUint16(nil, v),
+	}
+}
+
+// MagicUint32 returns the Magic for a format starting with the 32-bit value v
+// written in its own byte order.
+func MagicUint32(v uint32) Magic {
+	return Magic{
+		Big:    binary.BigEndian.AppendUint32(nil, v),
+		Little: binary.LittleEndian.AppendUint32(nil, v),
+	}
+}
+
+var (
+	// MagicTIFF matches the "MM" and "II" byte order marks of TIFF files.
+	MagicTIFF = Magic{Big: []byte("MM"), Little: []byte("II")}
+	// MagicPcap matches the magic number of pcap files with microsecond timestamps.
+	MagicPcap = MagicUint32(0xa1b2c3d4)
+	// MagicPcapNano matches the magic number of pcap files with nanosecond timestamps.
+	MagicPcapNano = MagicUint32(0xa1b23c4d)
+	// MagicUTF16 matches the UTF-16 byte order mark.
+	MagicUTF16 = MagicUint16(0xfeff)
+	// MagicUTF32 matches the UTF-32 byte order mark.
+	MagicUTF32 = MagicUint32(0x0000feff)
+)
+
+// peeker is implemented by bufio.Reader.
+type peeker interface {
+	Peek(n int) ([]byte, error)
+}
+
+// DetectReader looks at the first bytes of r to decide its byte order from m,
+// and returns a *BigEndianReader or *LittleEndianReader for it. The magic bytes
+// are not consumed: the returned reader starts with them at offset 0, so use it
+// instead of r afterwards.
+//
+// If r has a Peek method, like bufio.Reader, the bytes are peeked. Otherwise they
+// are read and put back in front of the rest of r, and are lost if an error is
+// returned.
+func DetectReader(r io.Reader, m Magic) (EndianReader, error) {
+	n := len(m.Big)
+	var head []byte
+	var err error
+	if p, ok := r.(peeker); ok {
+		head, err = p.Peek(n)
+		if err == io.EOF && len(head) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+	} else {
+		head = make([]byte, n)
+		var nn int
+		nn, err = io.ReadFull(r, head)
+		head = head[:nn]
+		r = io.MultiReader(bytes.NewReader(head), r)
+	}
+	if err != nil {
+		return nil, &OffsetError{Op: "DetectReader", Offset: 0, Width: n, N: len(head), Err: err}
+	}
+
+	switch {
+	case bytes.Equal(head, m.Big):
+		return NewBigEndianReader(r), nil
+	case bytes.Equal(head, m.Little):
+		return NewLittleEndianReader(r), nil
+	}
+	return nil, fmt.Errorf("%w: % x", ErrUnknownMagic, head)
+}
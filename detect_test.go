@@ -0,0 +1,83 @@
+package endianio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDetectReader(t *testing.T) {
+	var tests = []struct {
+		name  string
+		magic Magic
+		data  []byte
+		big   bool
+		want  uint16 // the uint16 after the magic
+	}{
+		{"TIFF_II", MagicTIFF, []byte{'I', 'I', 0x2a, 0x00}, false, 42},
+		{"TIFF_MM", MagicTIFF, []byte{'M', 'M', 0x00, 0x2a}, true, 42},
+		{"Pcap_BE", MagicPcap, []byte{0xa1, 0xb2, 0xc3, 0xd4, 0x00, 0x02}, true, 2},
+		{"Pcap_LE", MagicPcap, []byte{0xd4, 0xc3, 0xb2, 0xa1, 0x02, 0x00}, false, 2},
+		{"PcapNano_LE", MagicPcapNano, []byte{0x4d, 0x3c, 0xb2, 0xa1, 0x02, 0x00}, false, 2},
+		{"UTF16_BE", MagicUTF16, []byte{0xfe, 0xff, 0x00, 0x41}, true, 'A'},
+		{"UTF16_LE", MagicUTF16, []byte{0xff, 0xfe, 0x41, 0x00}, false, 'A'},
+		{"UTF32_LE", MagicUTF32, []byte{0xff, 0xfe, 0x00, 0x00, 0x41, 0x00}, false, 'A'},
+	}
+	readers := []struct {
+		name string
+		new  func(data []byte) io.Reader
+	}{
+		{"bytes.Reader", func(data []byte) io.Reader { return bytes.NewReader(data) }},
+		{"bufio.Reader", func(data []byte) io.Reader { return bufio.NewReader(bytes.NewReader(data)) }},
+		{"OneByteReader", func(data []byte) io.Reader { return iotest.OneByteReader(bytes.NewReader(data)) }},
+	}
+	for _, rd := range readers {
+		for _, tt := range tests {
+			t.Run(rd.name+"/"+tt.name, func(t *testing.T) {
+				r, err := DetectReader(rd.new(tt.data), tt.magic)
+				if err != nil {
+					t.Fatalf("DetectReader() error = %v", err)
+				}
+				_, isBig := r.(*BigEndianReader)
+				if isBig != tt.big {
+					t.Errorf("DetectReader() got = %T, want big-endian %v", r, tt.big)
+				}
+
+				// The magic is still there to be read
+				magic := make([]byte, len(tt.magic.Big))
+				if _, err := io.ReadFull(r.(io.Reader), magic); err != nil {
+					t.Fatalf("reading magic error = %v", err)
+				}
+				if !bytes.Equal(magic, tt.data[:len(magic)]) {
+					t.Errorf("magic got = %v, want %v", magic, tt.data[:len(magic)])
+				}
+				got, err := r.ReadUint16()
+				if err != nil {
+					t.Fatalf("ReadUint16() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("ReadUint16() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+
+	// Test error cases
+	t.Run("ErrorCases", func(t *testing.T) {
+		if _, err := DetectReader(bytes.NewReader([]byte("XX*\x00")), MagicTIFF); !errors.Is(err, ErrUnknownMagic) {
+			t.Errorf("DetectReader() error = %v, want %v", err, ErrUnknownMagic)
+		}
+		if _, err := DetectReader(bytes.NewReader(nil), MagicPcap); !errors.Is(err, io.EOF) {
+			t.Errorf("DetectReader() error = %v, want %v", err, io.EOF)
+		}
+		if _, err := DetectReader(bytes.NewReader([]byte{0xa1, 0xb2}), MagicPcap); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("DetectReader() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		if _, err := DetectReader(bufio.NewReader(bytes.NewReader([]byte{0xa1, 0xb2})), MagicPcap); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("DetectReader() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// baseEncoder provides common functionality for both big-endian and little-endian encoders.
+type baseEncoder struct {
+	buf []byte
+}
+
+// Write implements io.Writer, appending p to the encoded bytes. It never fails.
+func (e *baseEncoder) Write(p []byte) (n int, err error) {
+	e.buf = append(e.buf, p...)
+	return len(p), nil
+}
+
+// Bytes returns the encoded bytes. The slice aliases the encoder's buffer and is
+// only valid until the next write.
+func (e *baseEncoder) Bytes() []byte {
+	return e.buf
+}
+
+// Len returns the number of encoded bytes.
+func (e *baseEncoder) Len() int {
+	return len(e.buf)
+}
+
+// Reset discards the encoded bytes, but keeps the buffer for reuse.
+func (e *baseEncoder) Reset() {
+	e.buf = e.buf[:0]
+}
+
+// WriteUint8 writes a uint8 (byte)
+func (e *baseEncoder) WriteUint8(v uint8) (n int, err error) {
+	e.buf = append(e.buf, v)
+	return 1, nil
+}
+
+// WriteInt8 writes an int8
+func (e *baseEncoder) WriteInt8(v int8) (n int, err error) {
+	return e.WriteUint8(uint8(v))
+}
+
+// BigEndianEncoder encodes binary data in big-endian format by appending to a byte slice.
+// The write methods never fail.
+type BigEndianEncoder struct {
+	baseEncoder
+}
+
+// NewBigEndianEncoder creates a new BigEndianEncoder appending to buf, which may be nil.
+func NewBigEndianEncoder(buf []byte) *BigEndianEncoder {
+	return &BigEndianEncoder{baseEncoder{buf}}
+}
+
+// Order returns binary.BigEndian.
+func (e *BigEndianEncoder) Order() binary.ByteOrder {
+	return binary.BigEndian
+}
+
+// WriteUint16 writes a 16-bit unsigned integer in big-endian format.
+func (e *BigEndianEncoder) WriteUint16(v uint16) (n int, err error) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, v)
+	return 2, nil
+}
+
+// WriteUint32 writes a 32-bit unsigned integer in big-endian format.
+func (e *BigEndianEncoder) WriteUint32(v uint32) (n int, err error) {
+	e.buf = binary.BigEndian.AppendUint32(e.buf, v)
+	return 4, nil
+}
+
+// WriteUint64 writes a 64-bit unsigned integer in big-endian format.
+func (e *BigEndianEncoder) WriteUint64(v uint64) (n int, err error) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, v)
+	return 8, nil
+}
+
+// WriteInt16 writes a 16-bit signed integer in big-endian format.
+func (e *BigEndianEncoder) WriteInt16(v int16) (n int, err error) {
+	return e.WriteUint16(uint16(v))
+}
+
+// WriteInt32 writes a 32-bit signed integer in big-endian format.
+func (e *BigEndianEncoder) WriteInt32(v int32) (n int, err error) {
+	return e.WriteUint32(uint32(v))
+}
+
+// WriteInt64 writes a 64-bit signed integer in big-endian format.
+func (e *BigEndianEncoder) WriteInt64(v int64) (n int, err error) {
+	return e.WriteUint64(uint64(v))
+}
+
+// WriteFloat32 writes a 32-bit float encoded as a 32-bit unsigned integer in big-endian format.
+func (e *BigEndianEncoder) WriteFloat32(v float32) (n int, err error) {
+	return e.WriteUint32(math.Float32bits(v))
+}
+
+// WriteFloat64 writes a 64-bit float encoded as a 64-bit unsigned integer in big-endian format.
+func (e *BigEndianEncoder) WriteFloat64(v float64) (n int, err error) {
+	return e.WriteUint64(math.Float64bits(v))
+}
+
+// LittleEndianEncoder encodes binary data in little-endian format by appending to a byte slice.
+// The write methods never fail.
+type LittleEndianEncoder struct {
+	baseEncoder
+}
+
+// NewLittleEndianEncoder creates a new LittleEndianEncoder appending to buf, which may be nil.
+func NewLittleEndianEncoder(buf []byte) *LittleEndianEncoder {
+	return &LittleEndianEncoder{baseEncoder{buf}}
+}
+
+// Order returns binary.LittleEndian.
+func (e *LittleEndianEncoder) Order() binary.ByteOrder {
+	return binary.LittleEndian
+}
+
+// WriteUint16 writes a 16-bit unsigned integer in little-endian format.
+func (e *LittleEndianEncoder) WriteUint16(v uint16) (n int, err error) {
+	e.buf = binary.LittleEndian.AppendUint16(e.buf, v)
+	return 2, nil
+}
+
+// WriteUint32 writes a 32-bit unsigned integer in little-endian format.
+func (e *LittleEndianEncoder) WriteUint32(v uint32) (n int, err error) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+	return 4, nil
+}
+
+// WriteUint64 writes a 64-bit unsigned integer in little-endian format.
+func (e *LittleEndianEncoder) WriteUint64(v uint64) (n int, err error) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, v)
+	return 8, nil
+}
+
+// WriteInt16 writes a 16-bit signed integer in little-endian format.
+func (e *LittleEndianEncoder) WriteInt16(v int16) (n int, err error) {
+	return e.WriteUint16(uint16(v))
+}
+
+// WriteInt32 writes a 32-bit signed integer in little-endian format.
+func (e *LittleEndianEncoder) WriteInt32(v int32) (n int, err error) {
+	return e.WriteUint32(uint32(v))
+}
+
+// WriteInt64 writes a 64-bit signed integer in little-endian format.
+func (e *LittleEndianEncoder) WriteInt64(v int64) (n int, err error) {
+	return e.WriteUint64(uint64(v))
+}
+
+// WriteFloat32 writes a 32-bit float encoded as a 32-bit unsigned integer in little-endian format.
+func (e *LittleEndianEncoder) WriteFloat32(v float32) (n int, err error) {
+	return e.WriteUint32(math.Float32bits(v))
+}
+
+// WriteFloat64 writes a 64-bit float encoded as a 64-bit unsigned integer in little-endian format.
+func (e *LittleEndianEncoder) WriteFloat64(v float64) (n int, err error) {
+	return e.WriteUint64(math.Float64bits(v))
+}
@@ -0,0 +1,108 @@
+package endianio
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Compile time checks that the encoders satisfy EndianWriter
+var (
+	_ EndianWriter = (*BigEndianEncoder)(nil)
+	_ EndianWriter = (*LittleEndianEncoder)(nil)
+)
+
+func TestBigEndianEncoder(t *testing.T) {
+	e := NewBigEndianEncoder(nil)
+	e.WriteUint8(0xA5)
+	e.WriteUint16(0x1234)
+	e.WriteUint32(0x12345678)
+	e.WriteUint64(0x123456789ABCDEF0)
+	e.WriteInt16(-2)
+	e.WriteFloat32(0.1)
+	e.WriteFloat64(0.1)
+	want := []byte{
+		0xA5,
+		0x12, 0x34,
+		0x12, 0x34, 0x56, 0x78,
+		0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE, 0xF0,
+		0xFF, 0xFE,
+		0x3d, 0xcc, 0xcc, 0xcd,
+		0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a,
+	}
+	if got := e.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("Bytes() got = % X, want % X", got, want)
+	}
+	if e.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", e.Len(), len(want))
+	}
+}
+
+func TestLittleEndianEncoder(t *testing.T) {
+	e := NewLittleEndianEncoder(make([]byte, 0, 64))
+	e.WriteInt8(-0x5B)
+	e.WriteUint16(0x1234)
+	e.WriteUint32(0x12345678)
+	e.WriteInt64(0x123456789ABCDEF0)
+	e.WriteInt32(-2)
+	e.WriteFloat32(-0.1)
+	e.WriteFloat64(-0.1)
+	e.Write([]byte("ab"))
+	want := []byte{
+		0xA5,
+		0x34, 0x12,
+		0x78, 0x56, 0x34, 0x12,
+		0xF0, 0xDE, 0xBC, 0x9A, 0x78, 0x56, 0x34, 0x12,
+		0xFE, 0xFF, 0xFF, 0xFF,
+		0xcd, 0xcc, 0xcc, 0xbd,
+		0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0xbf,
+		'a', 'b',
+	}
+	if got := e.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("Bytes() got = % X, want % X", got, want)
+	}
+
+	e.Reset()
+	e.WriteUint16(0xABCD)
+	if got, want := e.Bytes(), []byte{0xCD, 0xAB}; !bytes.Equal(got, want) {
+		t.Errorf("Bytes() after Reset() got = % X, want % X", got, want)
+	}
+}
+
+func TestEncoderAllocations(t *testing.T) {
+	e := NewBigEndianEncoder(make([]byte, 0, 64))
+	allocs := testing.AllocsPerRun(100, func() {
+		e.Reset()
+		e.WriteUint16(0x1234)
+		e.WriteUint32(0x12345678)
+		e.WriteUint64(0x123456789ABCDEF0)
+	})
+	if allocs != 0 {
+		t.Errorf("encoding allocated %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkBigEndianEncoder_WriteUint32(b *testing.B) {
+	e := NewBigEndianEncoder(make([]byte, 0, 8))
+
+	for b.Loop() {
+		e.Reset()
+
+		_, err := e.WriteUint32(bigEndianUint32Value)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLittleEndianEncoder_WriteUint32(b *testing.B) {
+	e := NewLittleEndianEncoder(make([]byte, 0, 8))
+
+	for b.Loop() {
+		e.Reset()
+
+		_, err := e.WriteUint32(littleEndianUint32Value)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
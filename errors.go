@@ -0,0 +1,22 @@
+package endianio
+
+import "fmt"
+
+// OffsetError records a failed read or write and where in the stream it happened.
+// It wraps the underlying error, so errors.Is(err, io.ErrUnexpectedEOF) and
+// similar checks keep working.
+type OffsetError struct {
+	Op     string // the method that failed, e.g. "ReadUint32"
+	Offset int64  // offset of the value in the stream
+	Width  int    // number of bytes requested
+	N      int    // number of bytes actually read or written
+	Err    error  // the underlying error
+}
+
+func (e *OffsetError) Error() string {
+	return fmt.Sprintf("endianio: %s at offset %d (%d of %d bytes): %v", e.Op, e.Offset, e.N, e.Width, e.Err)
+}
+
+func (e *OffsetError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,120 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// shortWriter accepts at most n bytes in total, then fails.
+type shortWriter struct {
+	n int
+}
+
+func (sw *shortWriter) Write(p []byte) (n int, err error) {
+	n = min(len(p), sw.n)
+	sw.n -= n
+	if n < len(p) {
+		return n, errors.New("write failed")
+	}
+	return n, nil
+}
+
+func TestReaderOffset(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A}
+	r := NewBigEndianReader(bytes.NewReader(data))
+
+	r.ReadUint8()
+	r.ReadUint16()
+	r.ReadUvarint()
+	if got := r.Offset(); got != 4 {
+		t.Errorf("Offset() = %d, want 4", got)
+	}
+
+	_, err := r.ReadUint64()
+	var oe *OffsetError
+	if !errors.As(err, &oe) {
+		t.Fatalf("ReadUint64() error = %v, want an *OffsetError", err)
+	}
+	want := OffsetError{Op: "ReadUint64", Offset: 4, Width: 8, N: 6, Err: io.ErrUnexpectedEOF}
+	if *oe != want {
+		t.Errorf("ReadUint64() error = %+v, want %+v", *oe, want)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadUint64() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if got := r.Offset(); got != int64(len(data)) {
+		t.Errorf("Offset() = %d, want %d", got, len(data))
+	}
+
+	_, err = r.ReadFloat32()
+	if !errors.As(err, &oe) || oe.Op != "ReadFloat32" || oe.Offset != 10 || oe.N != 0 {
+		t.Errorf("ReadFloat32() error = %v", err)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("ReadFloat32() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestReaderOffsetVarint(t *testing.T) {
+	r := NewLittleEndianReader(bytes.NewReader([]byte{0x12, 0x34, 0x80, 0x80}))
+	r.ReadUint16()
+
+	_, err := r.ReadSLEB128()
+	var oe *OffsetError
+	if !errors.As(err, &oe) {
+		t.Fatalf("ReadSLEB128() error = %v, want an *OffsetError", err)
+	}
+	want := OffsetError{Op: "ReadSLEB128", Offset: 2, Width: 3, N: 2, Err: io.ErrUnexpectedEOF}
+	if *oe != want {
+		t.Errorf("ReadSLEB128() error = %+v, want %+v", *oe, want)
+	}
+}
+
+func TestDecoderOffsetError(t *testing.T) {
+	d := NewLittleEndianDecoder([]byte{0x01, 0x02, 0x03})
+	d.ReadUint16()
+
+	_, err := d.ReadInt32()
+	var oe *OffsetError
+	if !errors.As(err, &oe) {
+		t.Fatalf("ReadInt32() error = %v, want an *OffsetError", err)
+	}
+	want := OffsetError{Op: "ReadInt32", Offset: 2, Width: 4, N: 1, Err: io.ErrUnexpectedEOF}
+	if *oe != want {
+		t.Errorf("ReadInt32() error = %+v, want %+v", *oe, want)
+	}
+}
+
+func TestWriterOffset(t *testing.T) {
+	w := NewLittleEndianWriter(&shortWriter{n: 9})
+	w.WriteUint8(0x01)
+	w.WriteUint32(0x02030405)
+	if got := w.Offset(); got != 5 {
+		t.Errorf("Offset() = %d, want 5", got)
+	}
+
+	n, err := w.WriteInt64(-1)
+	if n != 4 {
+		t.Errorf("WriteInt64() n = %d, want 4", n)
+	}
+	var oe *OffsetError
+	if !errors.As(err, &oe) {
+		t.Fatalf("WriteInt64() error = %v, want an *OffsetError", err)
+	}
+	if oe.Op != "WriteInt64" || oe.Offset != 5 || oe.Width != 8 || oe.N != 4 {
+		t.Errorf("WriteInt64() error = %+v", *oe)
+	}
+	if got := w.Offset(); got != 9 {
+		t.Errorf("Offset() = %d, want 9", got)
+	}
+}
+
+func TestOffsetErrorMessage(t *testing.T) {
+	err := &OffsetError{Op: "ReadUint32", Offset: 12, Width: 4, N: 2, Err: io.ErrUnexpectedEOF}
+	want := "endianio: ReadUint32 at offset 12 (2 of 4 bytes): unexpected EOF"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
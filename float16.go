@@ -0,0 +1,159 @@
+package endianio
+
+import "math"
+
+// Float16frombits returns the value of the IEEE 754 binary16 (half precision)
+// bit pattern b. Every binary16 value, including subnormals and NaN payloads, is
+// exactly representable as a float32.
+func Float16frombits(b uint16) float32 {
+	return smallFloatFrombits(uint32(b), 5, 10)
+}
+
+// Float16bits returns the IEEE 754 binary16 bit pattern of f, rounded to nearest
+// even. Values too large for binary16 become infinities, and NaN payloads keep
+// their top 10 bits.
+func Float16bits(f float32) uint16 {
+	return uint16(smallFloatBits(f, 5, 10))
+}
+
+// BFloat16frombits returns the value of the bfloat16 bit pattern b, which is the
+// top half of a float32.
+func BFloat16frombits(b uint16) float32 {
+	return math.Float32frombits(uint32(b) << 16)
+}
+
+// BFloat16bits returns the bfloat16 bit pattern of f, rounded to nearest even.
+// NaN payloads keep their top 7 bits.
+func BFloat16bits(f float32) uint16 {
+	return uint16(smallFloatBits(f, 8, 7))
+}
+
+// smallFloatFrombits converts the bit pattern b of an IEEE 754 style binary
+// float with ebits exponent bits and mbits mantissa bits to a float32. ebits
+// must be at most 8 and mbits at most 23.
+func smallFloatFrombits(b uint32, ebits, mbits uint) float32 {
+	sign := (b >> (ebits + mbits)) & 1 << 31
+	exp := int(b>>mbits) & (1<<ebits - 1)
+	mant := b & (1<<mbits - 1)
+	bias := 1<<(ebits-1) - 1
+
+	switch exp {
+	case 0:
+		// Zero or subnormal, exact in float32
+		v := float32(math.Ldexp(float64(mant), 1-bias-int(mbits)))
+		return math.Float32frombits(sign | math.Float32bits(v))
+	case 1<<ebits - 1:
+		// Infinity or NaN, keeping the payload
+		return math.Float32frombits(sign | 0xff<<23 | mant<<(23-mbits))
+	}
+	return math.Float32frombits(sign | uint32(exp-bias+127)<<23 | mant<<(23-mbits))
+}
+
+// smallFloatBits converts f to the bit pattern of an IEEE 754 style binary float
+// with ebits exponent bits and mbits mantissa bits, rounding to nearest even.
+func smallFloatBits(f float32, ebits, mbits uint) uint32 {
+	b := math.Float32bits(f)
+	inf := uint32(1<<ebits-1) << mbits
+	if b&0x7f800000 == 0x7f800000 {
+		sign := b >> 31 << (ebits + mbits)
+		if mant32 := b & 0x7fffff; mant32 != 0 {
+			mant := mant32 >> (23 - mbits)
+			if mant == 0 {
+				mant = 1 << (mbits - 1)
+			}
+			return sign | inf | mant
+		}
+		return sign | inf
+	}
+	sign, mag := roundSmallFloat(f, ebits, mbits)
+	return sign | min(mag, inf)
+}
+
+// roundSmallFloat rounds the finite f to a binary float with ebits exponent bits
+// and mbits mantissa bits, to nearest even. It returns the sign bit in place and
+// the magnitude bits, whose exponent is not limited to ebits bits so the caller
+// can decide how to handle overflow.
+func roundSmallFloat(f float32, ebits, mbits uint) (sign, mag uint32) {
+	b := math.Float32bits(f)
+	sign = b >> 31 << (ebits + mbits)
+	exp32 := int(b>>23) & 0xff
+	mant32 := b & 0x7fffff
+	if exp32 == 0 && mant32 == 0 {
+		return sign, 0
+	}
+
+	// Normalize to sig * 2^(e-23) with the leading bit of sig at bit 23
+	sig := uint64(mant32)
+	e := exp32 - 127
+	if exp32 == 0 {
+		e = -126
+		for sig < 1<<23 {
+			sig <<= 1
+			e--
+		}
+	} else {
+		sig |= 1 << 23
+	}
+
+	biased := e + 1<<(ebits-1) - 1
+	if biased < 1 {
+		// Subnormal; rounding up to the smallest normal sets the exponent field
+		return sign, uint32(shiftRoundEven(sig, 23-mbits+uint(1-biased)))
+	}
+	q := shiftRoundEven(sig, 23-mbits)
+	if q == 1<<(mbits+1) {
+		q >>= 1
+		biased++
+	}
+	return sign, uint32(biased)<<mbits | uint32(q)&(1<<mbits-1)
+}
+
+// shiftRoundEven returns x >> s rounded to nearest, ties to even.
+func shiftRoundEven(x uint64, s uint) uint64 {
+	if s == 0 {
+		return x
+	}
+	if s >= 64 {
+		return 0
+	}
+	q := x >> s
+	r := x & (1<<s - 1)
+	half := uint64(1) << (s - 1)
+	if r > half || (r == half && q&1 == 1) {
+		q++
+	}
+	return q
+}
+
+// ReadFloat16 reads an IEEE 754 binary16 (half precision) float.
+func (r *baseReader) ReadFloat16() (float32, error) {
+	var b [2]byte
+	if err := r.readFull("ReadFloat16", b[:]); err != nil {
+		return 0, err
+	}
+	return Float16frombits(r.order.Uint16(b[:])), nil
+}
+
+// ReadBFloat16 reads a bfloat16 float.
+func (r *baseReader) ReadBFloat16() (float32, error) {
+	var b [2]byte
+	if err := r.readFull("ReadBFloat16", b[:]); err != nil {
+		return 0, err
+	}
+	return BFloat16frombits(r.order.Uint16(b[:])), nil
+}
+
+// WriteFloat16 writes v as an IEEE 754 binary16 (half precision) float, rounded
+// to nearest even.
+func (w *baseWriter) WriteFloat16(v float32) (n int, err error) {
+	var b [2]byte
+	w.order.PutUint16(b[:], Float16bits(v))
+	return w.write("WriteFloat16", b[:])
+}
+
+// WriteBFloat16 writes v as a bfloat16 float, rounded to nearest even.
+func (w *baseWriter) WriteBFloat16(v float32) (n int, err error) {
+	var b [2]byte
+	w.order.PutUint16(b[:], BFloat16bits(v))
+	return w.write("WriteBFloat16", b[:])
+}
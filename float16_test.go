@@ -0,0 +1,179 @@
+package endianio
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// float16Value computes the value of a binary16 bit pattern from its definition.
+func float16Value(b uint16) float64 {
+	sign := 1.0
+	if b&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(b>>10) & 0x1f
+	mant := float64(b & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(1+mant/1024, exp-15)
+}
+
+func TestFloat16(t *testing.T) {
+	// Test every bit pattern against the definition, and for a round trip
+	t.Run("Exhaustive", func(t *testing.T) {
+		for i := 0; i < 1<<16; i++ {
+			b := uint16(i)
+			got := Float16frombits(b)
+			want := float16Value(b)
+			if math.IsNaN(want) {
+				if !math.IsNaN(float64(got)) {
+					t.Fatalf("Float16frombits(%#04x) got = %v, want NaN", b, got)
+				}
+			} else if float64(got) != want || math.Signbit(float64(got)) != math.Signbit(want) {
+				t.Fatalf("Float16frombits(%#04x) got = %v, want %v", b, got, want)
+			}
+			if back := Float16bits(got); back != b {
+				t.Fatalf("Float16bits(Float16frombits(%#04x)) got = %#04x", b, back)
+			}
+		}
+	})
+
+	// Test rounding to nearest even between every pair of adjacent finite values
+	t.Run("Rounding", func(t *testing.T) {
+		for _, sign := range []uint16{0, 0x8000} {
+			for i := uint16(0); i < 0x7bff; i++ {
+				lo, hi := sign|i, sign|(i+1)
+				mid := (float16Value(lo) + float16Value(hi)) / 2
+				even := lo
+				if lo&1 == 1 {
+					even = hi
+				}
+				if got := Float16bits(float32(mid)); got != even {
+					t.Fatalf("Float16bits(%v) got = %#04x, want %#04x", mid, got, even)
+				}
+				below := math.Nextafter32(float32(mid), float32(float16Value(lo)))
+				if got := Float16bits(below); got != lo {
+					t.Fatalf("Float16bits(%v) got = %#04x, want %#04x", below, got, lo)
+				}
+				above := math.Nextafter32(float32(mid), float32(float16Value(hi)))
+				if got := Float16bits(above); got != hi {
+					t.Fatalf("Float16bits(%v) got = %#04x, want %#04x", above, got, hi)
+				}
+			}
+		}
+	})
+
+	// Test overflow, underflow and NaN payloads
+	t.Run("Special", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			f    float32
+			want uint16
+		}{
+			{"Max", 65504, 0x7bff},
+			{"BelowOverflow", math.Nextafter32(65520, 0), 0x7bff},
+			{"Overflow", 65520, 0x7c00},
+			{"Large", math.MaxFloat32, 0x7c00},
+			{"NegInf", float32(math.Inf(-1)), 0xfc00},
+			{"NegZero", float32(math.Copysign(0, -1)), 0x8000},
+			{"SmallestSubnormal", float32(math.Ldexp(1, -24)), 0x0001},
+			{"HalfSmallest", float32(math.Ldexp(1, -25)), 0x0000},
+			{"AboveHalfSmallest", math.Nextafter32(float32(math.Ldexp(1, -25)), 1), 0x0001},
+			{"Float32Subnormal", math.SmallestNonzeroFloat32, 0x0000},
+			{"NaNPayload", math.Float32frombits(0x7fc0_2000), 0x7e01},
+			{"NaNLowPayload", math.Float32frombits(0x7f80_0001), 0x7e00},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := Float16bits(tt.f); got != tt.want {
+					t.Errorf("Float16bits(%v) got = %#04x, want %#04x", tt.f, got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestBFloat16(t *testing.T) {
+	// Test every bit pattern against float32, and for a round trip
+	t.Run("Exhaustive", func(t *testing.T) {
+		for i := 0; i < 1<<16; i++ {
+			b := uint16(i)
+			got := BFloat16frombits(b)
+			if math.Float32bits(got) != uint32(b)<<16 {
+				t.Fatalf("BFloat16frombits(%#04x) got = %#08x", b, math.Float32bits(got))
+			}
+			if back := BFloat16bits(got); back != b {
+				t.Fatalf("BFloat16bits(BFloat16frombits(%#04x)) got = %#04x", b, back)
+			}
+		}
+	})
+
+	// Test rounding to nearest even between every pair of adjacent finite values
+	t.Run("Rounding", func(t *testing.T) {
+		for _, sign := range []uint16{0, 0x8000} {
+			for i := uint16(0); i < 0x7f7f; i++ {
+				lo, hi := sign|i, sign|(i+1)
+				mid := math.Float32frombits(uint32(lo)<<16 | 0x8000)
+				even := lo
+				if lo&1 == 1 {
+					even = hi
+				}
+				if got := BFloat16bits(mid); got != even {
+					t.Fatalf("BFloat16bits(%v) got = %#04x, want %#04x", mid, got, even)
+				}
+				if got := BFloat16bits(math.Float32frombits(math.Float32bits(mid) - 1)); got != lo {
+					t.Fatalf("BFloat16bits(below %v) got = %#04x, want %#04x", mid, got, lo)
+				}
+				if got := BFloat16bits(math.Float32frombits(math.Float32bits(mid) + 1)); got != hi {
+					t.Fatalf("BFloat16bits(above %v) got = %#04x, want %#04x", mid, got, hi)
+				}
+			}
+		}
+	})
+
+	if got := BFloat16bits(math.MaxFloat32); got != 0x7f80 {
+		t.Errorf("BFloat16bits(MaxFloat32) got = %#04x, want %#04x", got, 0x7f80)
+	}
+	if got := BFloat16bits(math.Float32frombits(0x7f80_0001)); got != 0x7fc0 {
+		t.Errorf("BFloat16bits(NaN) got = %#04x, want %#04x", got, 0x7fc0)
+	}
+}
+
+func TestReadWriteFloat16(t *testing.T) {
+	buf := &bytes.Buffer{}
+	be := NewBigEndianWriter(buf)
+	be.WriteFloat16(1.5)
+	be.WriteBFloat16(-2)
+	le := NewLittleEndianWriter(buf)
+	le.WriteFloat16(1.5)
+	le.WriteBFloat16(-2)
+
+	want := []byte{0x3e, 0x00, 0xc0, 0x00, 0x00, 0x3e, 0x00, 0xc0}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("WriteFloat16() got = %v, want %v", buf.Bytes(), want)
+	}
+
+	r := bytes.NewReader(want)
+	br := NewBigEndianReader(r)
+	if got, err := br.ReadFloat16(); err != nil || got != 1.5 {
+		t.Errorf("ReadFloat16() got = %v, %v, want %v", got, err, 1.5)
+	}
+	if got, err := br.ReadBFloat16(); err != nil || got != -2 {
+		t.Errorf("ReadBFloat16() got = %v, %v, want %v", got, err, -2)
+	}
+	lr := NewLittleEndianReader(r)
+	if got, err := lr.ReadFloat16(); err != nil || got != 1.5 {
+		t.Errorf("ReadFloat16() got = %v, %v, want %v", got, err, 1.5)
+	}
+	if got, err := lr.ReadBFloat16(); err != nil || got != -2 {
+		t.Errorf("ReadBFloat16() got = %v, %v, want %v", got, err, -2)
+	}
+}
@@ -0,0 +1,174 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Float80 is the bit pattern of an x87 80-bit extended precision float, as used
+// by AIFF sample rates and x87 register dumps. Unlike float64 the integer bit of
+// the significand is explicit.
+type Float80 struct {
+	SignExp uint16 // sign bit and 15-bit exponent biased by 16383
+	Mant    uint64 // significand, with the integer bit at bit 63
+}
+
+// Float80FromFloat64 returns the Float80 for v. The conversion is exact.
+func Float80FromFloat64(v float64) Float80 {
+	b := math.Float64bits(v)
+	sign := uint16(b>>63) << 15
+	exp := int(b>>52) & 0x7ff
+	frac := b & (1<<52 - 1)
+	switch exp {
+	case 0x7ff:
+		// Infinity or NaN, keeping the payload
+		return Float80{SignExp: sign | 0x7fff, Mant: 1<<63 | frac<<11}
+	case 0:
+		if frac == 0 {
+			return Float80{SignExp: sign}
+		}
+		// Subnormal float64 values are normal extended floats
+		lz := bits.LeadingZeros64(frac)
+		return Float80{SignExp: sign | uint16(-1074+63-lz+16383), Mant: frac << lz}
+	}
+	return Float80{SignExp: sign | uint16(exp-1023+16383), Mant: 1<<63 | frac<<11}
+}
+
+// isNaN reports whether f is a NaN, or one of the encodings the 80387 and later
+// reject as invalid operands: pseudo-NaNs, pseudo-infinities and unnormals.
+func (f Float80) isNaN() bool {
+	exp := f.SignExp & 0x7fff
+	switch {
+	case exp == 0x7fff:
+		return f.Mant != 1<<63
+	case exp != 0:
+		return f.Mant>>63 == 0
+	}
+	return false
+}
+
+// unbiased returns the exponent of f for its significand read as 1.63 fixed
+// point. Denormals and pseudo-denormals share the exponent of the smallest
+// normal numbers.
+func (f Float80) unbiased() int {
+	exp := int(f.SignExp & 0x7fff)
+	if exp == 0 {
+		exp = 1
+	}
+	return exp - 16383
+}
+
+// Float64 returns f rounded to nearest even as a float64. Values too large
+// become infinities and values too small become zero or subnormals. NaN
+// payloads keep their top 51 bits. Unnormals, pseudo-NaNs and pseudo-infinities,
+// which the 80387 and later reject, become NaN; pseudo-denormals are read by
+// value.
+func (f Float80) Float64() float64 {
+	sign := uint64(f.SignExp>>15) << 63
+	switch {
+	case f.isNaN():
+		frac := f.Mant << 1 >> 12
+		if frac == 0 {
+			frac |= 1 << 51
+		}
+		return math.Float64frombits(sign | 0x7ff<<52 | frac)
+	case f.SignExp&0x7fff == 0x7fff:
+		return math.Float64frombits(sign | 0x7ff<<52)
+	case f.Mant == 0:
+		return math.Float64frombits(sign)
+	}
+
+	// Normalize so the leading 1 is at bit 63, for pseudo-denormals and denormals
+	e := f.unbiased()
+	lz := bits.LeadingZeros64(f.Mant)
+	m := f.Mant << lz
+	e -= lz
+
+	if e >= -1022 {
+		q := shiftRoundEven(m, 11)
+		if q == 1<<53 {
+			q >>= 1
+			e++
+		}
+		if e > 1023 {
+			return math.Float64frombits(sign | 0x7ff<<52)
+		}
+		return math.Float64frombits(sign | uint64(e+1023)<<52 | q&(1<<52-1))
+	}
+	// Subnormal; rounding up to the smallest normal sets the exponent field
+	return math.Float64frombits(sign | shiftRoundEven(m, uint(11-1022-e)))
+}
+
+// BigFloat returns f exactly, with 64 bits of precision, or nil if f is a NaN
+// or one of the encodings the 80387 and later reject.
+func (f Float80) BigFloat() *big.Float {
+	neg := f.SignExp>>15 != 0
+	switch {
+	case f.isNaN():
+		return nil
+	case f.SignExp&0x7fff == 0x7fff:
+		return new(big.Float).SetInf(neg)
+	}
+	x := new(big.Float).SetPrec(64).SetUint64(f.Mant)
+	x.SetMantExp(x, f.unbiased()-63)
+	if neg {
+		x.Neg(x)
+	}
+	return x
+}
+
+// ReadFloat80Bits reads an 80-bit extended precision float as its bit pattern.
+// In big-endian, as in AIFF, the sign and exponent come first; in little-endian,
+// as on x87, the significand does.
+func (r *baseReader) ReadFloat80Bits() (Float80, error) {
+	var b [10]byte
+	if err := r.readFull("ReadFloat80Bits", b[:]); err != nil {
+		return Float80{}, err
+	}
+	return getFloat80(r.order, b[:]), nil
+}
+
+// ReadFloat80 reads an 80-bit extended precision float, rounded to a float64 as
+// described for Float80.Float64. Use ReadFloat80Bits to keep the full precision.
+func (r *baseReader) ReadFloat80() (float64, error) {
+	var b [10]byte
+	if err := r.readFull("ReadFloat80", b[:]); err != nil {
+		return 0, err
+	}
+	return getFloat80(r.order, b[:]).Float64(), nil
+}
+
+// WriteFloat80Bits writes the 80-bit extended precision float f.
+func (w *baseWriter) WriteFloat80Bits(f Float80) (n int, err error) {
+	var b [10]byte
+	putFloat80(w.order, b[:], f)
+	return w.write("WriteFloat80Bits", b[:])
+}
+
+// WriteFloat80 writes v as an 80-bit extended precision float, which is exact.
+func (w *baseWriter) WriteFloat80(v float64) (n int, err error) {
+	var b [10]byte
+	putFloat80(w.order, b[:], Float80FromFloat64(v))
+	return w.write("WriteFloat80", b[:])
+}
+
+func getFloat80(order binary.ByteOrder, b []byte) Float80 {
+	order = plainOrder(order)
+	if order == binary.BigEndian {
+		return Float80{SignExp: order.Uint16(b), Mant: order.Uint64(b[2:])}
+	}
+	return Float80{SignExp: order.Uint16(b[8:]), Mant: order.Uint64(b)}
+}
+
+func putFloat80(order binary.ByteOrder, b []byte, f Float80) {
+	order = plainOrder(order)
+	if order == binary.BigEndian {
+		order.PutUint16(b, f.SignExp)
+		order.PutUint64(b[2:], f.Mant)
+	} else {
+		order.PutUint64(b, f.Mant)
+		order.PutUint16(b[8:], f.SignExp)
+	}
+}
@@ -0,0 +1,129 @@
+package endianio
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloat80(t *testing.T) {
+	var tests = []struct {
+		name string
+		f    Float80
+		want float64
+	}{
+		{"One", Float80{0x3fff, 0x8000000000000000}, 1},
+		{"MinusTwo", Float80{0xc000, 0x8000000000000000}, -2},
+		{"AIFF44100", Float80{0x400e, 0xac44000000000000}, 44100},
+		{"Pi", Float80{0x4000, 0xc90fdaa22168c000}, math.Pi},
+		{"Zero", Float80{0x0000, 0}, 0},
+		{"NegZero", Float80{0x8000, 0}, math.Copysign(0, -1)},
+		{"Inf", Float80{0x7fff, 0x8000000000000000}, math.Inf(1)},
+		{"NegInf", Float80{0xffff, 0x8000000000000000}, math.Inf(-1)},
+		{"Overflow", Float80{0x43ff, 0x8000000000000000}, math.Inf(1)},
+		{"Underflow", Float80{0x0001, 0x8000000000000000}, 0},
+		{"PseudoDenormal", Float80{0x0000, 0x8000000000000000}, 0},
+		{"MaxFloat64", Float80{0x43fe, 0xfffffffffffff800}, math.MaxFloat64},
+		{"RoundsToMaxFloat64", Float80{0x43fe, 0xfffffffffffffbff}, math.MaxFloat64},
+		{"RoundsToInf", Float80{0x43fe, 0xfffffffffffffc00}, math.Inf(1)},
+		{"SmallestSubnormal", Float80{16383 - 1074, 0x8000000000000000}, math.SmallestNonzeroFloat64},
+		{"SubnormalTieToEven", Float80{16383 - 1074, 0xc000000000000000}, math.Ldexp(1, -1073)},
+		{"RoundToNearestEven", Float80{0x3fff, 0x8000000000000400}, 1},
+		{"RoundUp", Float80{0x3fff, 0x8000000000000401}, 1 + math.Ldexp(1, -52)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.f.Float64()
+			if got != tt.want || math.Signbit(got) != math.Signbit(tt.want) {
+				t.Errorf("Float64() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Encodings the 80387 and later reject as invalid operands read as NaN
+	var nans = []struct {
+		name string
+		f    Float80
+	}{
+		{"NaN", Float80{0x7fff, 0xc000000000000000}},
+		{"SignalingNaN", Float80{0x7fff, 0x8000000000000001}},
+		{"PseudoInfinity", Float80{0x7fff, 0x0000000000000000}},
+		{"PseudoNaN", Float80{0x7fff, 0x4000000000000000}},
+		{"Unnormal", Float80{0x3fff, 0x4000000000000000}},
+	}
+	for _, tt := range nans {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.Float64(); !math.IsNaN(got) {
+				t.Errorf("Float64() got = %v, want NaN", got)
+			}
+			if got := tt.f.BigFloat(); got != nil {
+				t.Errorf("BigFloat() got = %v, want nil", got)
+			}
+		})
+	}
+
+	// Test round trips of float64 values
+	t.Run("RoundTrip", func(t *testing.T) {
+		values := []float64{0, 1, -1.5, math.Pi, 1e300, -1e-300, math.MaxFloat64, math.SmallestNonzeroFloat64, 3 * math.SmallestNonzeroFloat64, math.Inf(-1)}
+		for _, v := range values {
+			if got := Float80FromFloat64(v).Float64(); got != v {
+				t.Errorf("Float80FromFloat64(%v).Float64() got = %v", v, got)
+			}
+		}
+		nan := math.Float64frombits(0x7ff4000000000001)
+		if got := Float80FromFloat64(nan).Float64(); math.Float64bits(got) != math.Float64bits(nan) {
+			t.Errorf("NaN payload got = %#x, want %#x", math.Float64bits(got), math.Float64bits(nan))
+		}
+	})
+
+	// Test exact conversion to big.Float, beyond the float64 range and precision
+	t.Run("BigFloat", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			f    Float80
+			want *big.Float
+		}{
+			{"One", Float80{0x3fff, 0x8000000000000000}, big.NewFloat(1)},
+			{"FullPrecision", Float80{0x3fff, 0x8000000000000001}, new(big.Float).SetMantExp(new(big.Float).SetUint64(1<<63|1), -63)},
+			{"Denormal", Float80{0x0000, 0x0000000000000001}, new(big.Float).SetMantExp(big.NewFloat(1), -16445)},
+			{"PseudoDenormal", Float80{0x0000, 0x8000000000000000}, new(big.Float).SetMantExp(big.NewFloat(1), -16382)},
+			{"Huge", Float80{0xfffe, 0x8000000000000000}, new(big.Float).SetMantExp(big.NewFloat(-1), 16383)},
+			{"Inf", Float80{0xffff, 0x8000000000000000}, new(big.Float).SetInf(true)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := tt.f.BigFloat(); got == nil || got.Cmp(tt.want) != 0 {
+					t.Errorf("BigFloat() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestReadWriteFloat80(t *testing.T) {
+	aiff := []byte{0x40, 0x0e, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	x87 := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0xac, 0x0e, 0x40}
+
+	if got, err := NewBigEndianReader(bytes.NewReader(aiff)).ReadFloat80(); err != nil || got != 44100 {
+		t.Errorf("ReadFloat80() big-endian got = %v, %v, want 44100", got, err)
+	}
+	if got, err := NewLittleEndianReader(bytes.NewReader(x87)).ReadFloat80(); err != nil || got != 44100 {
+		t.Errorf("ReadFloat80() little-endian got = %v, %v, want 44100", got, err)
+	}
+	want := Float80{0x400e, 0xac44000000000000}
+	if got, err := NewLittleEndianReader(bytes.NewReader(x87)).ReadFloat80Bits(); err != nil || got != want {
+		t.Errorf("ReadFloat80Bits() got = %v, %v, want %v", got, err, want)
+	}
+
+	buf := &bytes.Buffer{}
+	NewBigEndianWriter(buf).WriteFloat80(44100)
+	if !bytes.Equal(buf.Bytes(), aiff) {
+		t.Errorf("WriteFloat80() big-endian got = %v, want %v", buf.Bytes(), aiff)
+	}
+	buf.Reset()
+	NewLittleEndianWriter(buf).WriteFloat80Bits(want)
+	if !bytes.Equal(buf.Bytes(), x87) {
+		t.Errorf("WriteFloat80Bits() little-endian got = %v, want %v", buf.Bytes(), x87)
+	}
+}
@@ -0,0 +1,194 @@
+package endianio
+
+import "math"
+
+// FP8Overflow selects what encoding to FP8 does with values beyond the largest
+// finite value.
+type FP8Overflow int
+
+const (
+	// FP8Saturate clamps them, including infinities, to the largest finite value
+	// with the same sign. NaN stays NaN.
+	FP8Saturate FP8Overflow = iota
+	// FP8NoSaturate turns them into NaN for E4M3, which has no infinities, and
+	// into infinities for E5M2.
+	FP8NoSaturate
+)
+
+const (
+	e4m3Max = 0x7e // 448
+	e4m3NaN = 0x7f
+	e5m2Max = 0x7b // 57344
+	e5m2Inf = 0x7c
+)
+
+// FP8E4M3frombits returns the value of the FP8 E4M3 bit pattern b. E4M3 is the
+// OCP FP8 format with 4 exponent bits, 3 mantissa bits and a bias of 7. It has no
+// infinities; S.1111.111 is NaN, and the largest finite value is 448.
+func FP8E4M3frombits(b uint8) float32 {
+	sign := uint32(b>>7) << 31
+	switch {
+	case b&0x7f == e4m3NaN:
+		return math.Float32frombits(sign | 0x7fc00000)
+	case b&0x78 == 0x78:
+		// The top exponent holds normal numbers, unlike in IEEE 754
+		return math.Float32frombits(sign | uint32(15-7+127)<<23 | uint32(b&0x07)<<20)
+	}
+	return smallFloatFrombits(uint32(b), 4, 3)
+}
+
+// FP8E4M3bits returns the FP8 E4M3 bit pattern of f, rounded to nearest even,
+// with overflow handled as selected by mode.
+func FP8E4M3bits(f float32, mode FP8Overflow) uint8 {
+	sign := uint8(math.Float32bits(f) >> 31 << 7)
+	switch {
+	case f != f:
+		return sign | e4m3NaN
+	case math.IsInf(float64(f), 0):
+		if mode == FP8Saturate {
+			return sign | e4m3Max
+		}
+		return sign | e4m3NaN
+	}
+	_, mag := roundSmallFloat(f, 4, 3)
+	if mag > e4m3Max {
+		if mode == FP8Saturate {
+			return sign | e4m3Max
+		}
+		return sign | e4m3NaN
+	}
+	return sign | uint8(mag)
+}
+
+// FP8E5M2frombits returns the value of the FP8 E5M2 bit pattern b. E5M2 is the
+// OCP FP8 format with 5 exponent bits, 2 mantissa bits and a bias of 15. It
+// follows the IEEE 754 rules, and the largest finite value is 57344.
+func FP8E5M2frombits(b uint8) float32 {
+	return smallFloatFrombits(uint32(b), 5, 2)
+}
+
+// FP8E5M2bits returns the FP8 E5M2 bit pattern of f, rounded to nearest even,
+// with overflow handled as selected by mode.
+func FP8E5M2bits(f float32, mode FP8Overflow) uint8 {
+	if f != f {
+		return uint8(smallFloatBits(f, 5, 2))
+	}
+	sign := uint8(math.Float32bits(f) >> 31 << 7)
+	mag := uint32(e5m2Inf)
+	if !math.IsInf(float64(f), 0) {
+		_, mag = roundSmallFloat(f, 5, 2)
+	}
+	if mag >= e5m2Inf {
+		if mode == FP8Saturate {
+			return sign | e5m2Max
+		}
+		return sign | e5m2Inf
+	}
+	return sign | uint8(mag)
+}
+
+// DecodeFP8E4M3 converts the FP8 E4M3 values in src to dst, which must be at
+// least as long as src, and returns the number of values converted.
+func DecodeFP8E4M3(dst []float32, src []byte) int {
+	for i, b := range src {
+		dst[i] = FP8E4M3frombits(b)
+	}
+	return len(src)
+}
+
+// EncodeFP8E4M3 converts the values in src to FP8 E4M3 in dst, which must be at
+// least as long as src, and returns the number of values converted.
+func EncodeFP8E4M3(dst []byte, src []float32, mode FP8Overflow) int {
+	for i, f := range src {
+		dst[i] = FP8E4M3bits(f, mode)
+	}
+	return len(src)
+}
+
+// DecodeFP8E5M2 converts the FP8 E5M2 values in src to dst, which must be at
+// least as long as src, and returns the number of values converted.
+func DecodeFP8E5M2(dst []float32, src []byte) int {
+	for i, b := range src {
+		dst[i] = FP8E5M2frombits(b)
+	}
+	return len(src)
+}
+
+// EncodeFP8E5M2 converts the values in src to FP8 E5M2 in dst, which must be at
+// least as long as src, and returns the number of values converted.
+func EncodeFP8E5M2(dst []byte, src []float32, mode FP8Overflow) int {
+	for i, f := range src {
+		dst[i] = FP8E5M2bits(f, mode)
+	}
+	return len(src)
+}
+
+// ReadFP8E4M3 reads an FP8 E4M3 float.
+func (r *baseReader) ReadFP8E4M3() (float32, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, &OffsetError{Op: "ReadFP8E4M3", Offset: r.off, Width: 1, Err: err}
+	}
+	return FP8E4M3frombits(b), nil
+}
+
+// ReadFP8E5M2 reads an FP8 E5M2 float.
+func (r *baseReader) ReadFP8E5M2() (float32, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, &OffsetError{Op: "ReadFP8E5M2", Offset: r.off, Width: 1, Err: err}
+	}
+	return FP8E5M2frombits(b), nil
+}
+
+// ReadFP8E4M3s fills dst with FP8 E4M3 floats.
+func (r *baseReader) ReadFP8E4M3s(dst []float32) error {
+	if err := r.checkLength("ReadFP8E4M3s", r.off, len(dst), 1); err != nil {
+		return err
+	}
+	b := make([]byte, len(dst))
+	if err := r.readFull("ReadFP8E4M3s", b); err != nil {
+		return err
+	}
+	DecodeFP8E4M3(dst, b)
+	return nil
+}
+
+// ReadFP8E5M2s fills dst with FP8 E5M2 floats.
+func (r *baseReader) ReadFP8E5M2s(dst []float32) error {
+	if err := r.checkLength("ReadFP8E5M2s", r.off, len(dst), 1); err != nil {
+		return err
+	}
+	b := make([]byte, len(dst))
+	if err := r.readFull("ReadFP8E5M2s", b); err != nil {
+		return err
+	}
+	DecodeFP8E5M2(dst, b)
+	return nil
+}
+
+// WriteFP8E4M3 writes v as an FP8 E4M3 float.
+func (w *baseWriter) WriteFP8E4M3(v float32, mode FP8Overflow) (n int, err error) {
+	b := [1]byte{FP8E4M3bits(v, mode)}
+	return w.write("WriteFP8E4M3", b[:])
+}
+
+// WriteFP8E5M2 writes v as an FP8 E5M2 float.
+func (w *baseWriter) WriteFP8E5M2(v float32, mode FP8Overflow) (n int, err error) {
+	b := [1]byte{FP8E5M2bits(v, mode)}
+	return w.write("WriteFP8E5M2", b[:])
+}
+
+// WriteFP8E4M3s writes the values in v as FP8 E4M3 floats.
+func (w *baseWriter) WriteFP8E4M3s(v []float32, mode FP8Overflow) (n int, err error) {
+	b := make([]byte, len(v))
+	EncodeFP8E4M3(b, v, mode)
+	return w.write("WriteFP8E4M3s", b)
+}
+
+// WriteFP8E5M2s writes the values in v as FP8 E5M2 floats.
+func (w *baseWriter) WriteFP8E5M2s(v []float32, mode FP8Overflow) (n int, err error) {
+	b := make([]byte, len(v))
+	EncodeFP8E5M2(b, v, mode)
+	return w.write("WriteFP8E5M2s", b)
+}
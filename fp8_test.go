@@ -0,0 +1,226 @@
+package endianio
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// fp8Value computes the value of an FP8 bit pattern from its definition.
+func fp8Value(b uint8, ebits, mbits uint, ieee bool) float64 {
+	sign := 1.0
+	if b&0x80 != 0 {
+		sign = -1
+	}
+	bias := 1<<(ebits-1) - 1
+	exp := int(b>>mbits) & (1<<ebits - 1)
+	mant := float64(b & (1<<mbits - 1))
+	switch {
+	case ieee && exp == 1<<ebits-1:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	case !ieee && b&0x7f == 0x7f:
+		return math.NaN()
+	case exp == 0:
+		return sign * math.Ldexp(mant, 1-bias-int(mbits))
+	}
+	return sign * math.Ldexp(1+mant/float64(uint(1)<<mbits), exp-bias)
+}
+
+func TestFP8Tables(t *testing.T) {
+	// Values from the OCP 8-bit floating point specification
+	var tests = []struct {
+		name string
+		e4m3 bool
+		b    uint8
+		want float64
+	}{
+		{"E4M3_MinSubnormal", true, 0x01, math.Ldexp(1, -9)},
+		{"E4M3_MaxSubnormal", true, 0x07, 0.875 * math.Ldexp(1, -6)},
+		{"E4M3_MinNormal", true, 0x08, math.Ldexp(1, -6)},
+		{"E4M3_One", true, 0x38, 1},
+		{"E4M3_Max", true, 0x7e, 448},
+		{"E4M3_NegMax", true, 0xfe, -448},
+		{"E4M3_Exp15", true, 0x78, 256},
+		{"E5M2_MinSubnormal", false, 0x01, math.Ldexp(1, -16)},
+		{"E5M2_MaxSubnormal", false, 0x03, 0.75 * math.Ldexp(1, -14)},
+		{"E5M2_MinNormal", false, 0x04, math.Ldexp(1, -14)},
+		{"E5M2_One", false, 0x3c, 1},
+		{"E5M2_Max", false, 0x7b, 57344},
+		{"E5M2_Inf", false, 0x7c, math.Inf(1)},
+		{"E5M2_NegInf", false, 0xfc, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got float32
+			var back uint8
+			if tt.e4m3 {
+				got = FP8E4M3frombits(tt.b)
+				back = FP8E4M3bits(got, FP8NoSaturate)
+			} else {
+				got = FP8E5M2frombits(tt.b)
+				back = FP8E5M2bits(got, FP8NoSaturate)
+			}
+			if float64(got) != tt.want {
+				t.Errorf("frombits(%#02x) got = %v, want %v", tt.b, got, tt.want)
+			}
+			if back != tt.b {
+				t.Errorf("bits(%v) got = %#02x, want %#02x", got, back, tt.b)
+			}
+		})
+	}
+
+	for _, b := range []uint8{0x7f, 0xff} {
+		if got := FP8E4M3frombits(b); !math.IsNaN(float64(got)) {
+			t.Errorf("FP8E4M3frombits(%#02x) got = %v, want NaN", b, got)
+		}
+	}
+	for _, b := range []uint8{0x7d, 0x7e, 0x7f, 0xfd} {
+		if got := FP8E5M2frombits(b); !math.IsNaN(float64(got)) {
+			t.Errorf("FP8E5M2frombits(%#02x) got = %v, want NaN", b, got)
+		}
+	}
+}
+
+func TestFP8Exhaustive(t *testing.T) {
+	var formats = []struct {
+		name     string
+		ebits    uint
+		mbits    uint
+		ieee     bool
+		frombits func(uint8) float32
+		bits     func(float32, FP8Overflow) uint8
+	}{
+		{"E4M3", 4, 3, false, FP8E4M3frombits, FP8E4M3bits},
+		{"E5M2", 5, 2, true, FP8E5M2frombits, FP8E5M2bits},
+	}
+	for _, ff := range formats {
+		t.Run(ff.name, func(t *testing.T) {
+			// Every bit pattern against the definition, and for a round trip
+			for i := 0; i < 256; i++ {
+				b := uint8(i)
+				got := ff.frombits(b)
+				want := fp8Value(b, ff.ebits, ff.mbits, ff.ieee)
+				if math.IsNaN(want) {
+					if !math.IsNaN(float64(got)) {
+						t.Fatalf("frombits(%#02x) got = %v, want NaN", b, got)
+					}
+					if back := ff.bits(got, FP8NoSaturate); !math.IsNaN(float64(ff.frombits(back))) {
+						t.Fatalf("bits(NaN) got = %#02x, want NaN", back)
+					}
+					continue
+				}
+				if float64(got) != want || math.Signbit(float64(got)) != math.Signbit(want) {
+					t.Fatalf("frombits(%#02x) got = %v, want %v", b, got, want)
+				}
+				if back := ff.bits(got, FP8NoSaturate); back != b {
+					t.Fatalf("bits(frombits(%#02x)) got = %#02x", b, back)
+				}
+			}
+
+			// Rounding to nearest even between every pair of adjacent finite values
+			for _, sign := range []uint8{0, 0x80} {
+				for i := uint8(0); i < 0x7f; i++ {
+					lo, hi := sign|i, sign|(i+1)
+					vlo, vhi := fp8Value(lo, ff.ebits, ff.mbits, ff.ieee), fp8Value(hi, ff.ebits, ff.mbits, ff.ieee)
+					if math.IsNaN(vhi) || math.IsInf(vhi, 0) {
+						break
+					}
+					mid := float32((vlo + vhi) / 2)
+					even := lo
+					if lo&1 == 1 {
+						even = hi
+					}
+					if got := ff.bits(mid, FP8NoSaturate); got != even {
+						t.Fatalf("bits(%v) got = %#02x, want %#02x", mid, got, even)
+					}
+					if got := ff.bits(math.Nextafter32(mid, float32(vlo)), FP8NoSaturate); got != lo {
+						t.Fatalf("bits(below %v) got = %#02x, want %#02x", mid, got, lo)
+					}
+					if got := ff.bits(math.Nextafter32(mid, float32(vhi)), FP8NoSaturate); got != hi {
+						t.Fatalf("bits(above %v) got = %#02x, want %#02x", mid, got, hi)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFP8Overflow(t *testing.T) {
+	inf := float32(math.Inf(1))
+	var tests = []struct {
+		name string
+		e4m3 bool
+		f    float32
+		mode FP8Overflow
+		want uint8
+	}{
+		{"E4M3_TieToMax", true, 464, FP8NoSaturate, 0x7e},
+		{"E4M3_Overflow", true, 465, FP8NoSaturate, 0x7f},
+		{"E4M3_OverflowSat", true, 465, FP8Saturate, 0x7e},
+		{"E4M3_NegOverflowSat", true, -1e6, FP8Saturate, 0xfe},
+		{"E4M3_Inf", true, inf, FP8NoSaturate, 0x7f},
+		{"E4M3_InfSat", true, inf, FP8Saturate, 0x7e},
+		{"E4M3_NaNSat", true, float32(math.NaN()), FP8Saturate, 0x7f},
+		{"E5M2_TieToInf", false, 61440, FP8NoSaturate, 0x7c},
+		{"E5M2_BelowTie", false, math.Nextafter32(61440, 0), FP8NoSaturate, 0x7b},
+		{"E5M2_OverflowSat", false, 61440, FP8Saturate, 0x7b},
+		{"E5M2_Inf", false, inf, FP8NoSaturate, 0x7c},
+		{"E5M2_NegInfSat", false, -inf, FP8Saturate, 0xfb},
+		{"E5M2_NaNSat", false, float32(math.NaN()), FP8Saturate, 0x7e},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uint8
+			if tt.e4m3 {
+				got = FP8E4M3bits(tt.f, tt.mode)
+			} else {
+				got = FP8E5M2bits(tt.f, tt.mode)
+			}
+			if got != tt.want {
+				t.Errorf("bits(%v) got = %#02x, want %#02x", tt.f, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadWriteFP8(t *testing.T) {
+	values := []float32{1, -0.5, 448, 1000}
+	buf := &bytes.Buffer{}
+	w := NewLittleEndianWriter(buf)
+	if _, err := w.WriteFP8E4M3s(values, FP8Saturate); err != nil {
+		t.Fatalf("WriteFP8E4M3s() error = %v", err)
+	}
+	w.WriteFP8E5M2(-2, FP8Saturate)
+	w.WriteFP8E4M3(1, FP8Saturate)
+	w.WriteFP8E5M2s([]float32{1, 1e6}, FP8NoSaturate)
+
+	want := []byte{0x38, 0xb0, 0x7e, 0x7e, 0xc0, 0x38, 0x3c, 0x7c}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("write got = %#v, want %#v", buf.Bytes(), want)
+	}
+
+	r := NewBigEndianReader(buf)
+	got := make([]float32, 4)
+	if err := r.ReadFP8E4M3s(got); err != nil {
+		t.Fatalf("ReadFP8E4M3s() error = %v", err)
+	}
+	if want := []float32{1, -0.5, 448, 448}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+		t.Errorf("ReadFP8E4M3s() got = %v, want %v", got, want)
+	}
+	if v, err := r.ReadFP8E5M2(); err != nil || v != -2 {
+		t.Errorf("ReadFP8E5M2() got = %v, %v, want %v", v, err, -2)
+	}
+	if v, err := r.ReadFP8E4M3(); err != nil || v != 1 {
+		t.Errorf("ReadFP8E4M3() got = %v, %v, want %v", v, err, 1)
+	}
+	got = got[:2]
+	if err := r.ReadFP8E5M2s(got); err != nil || got[0] != 1 || !math.IsInf(float64(got[1]), 1) {
+		t.Errorf("ReadFP8E5M2s() got = %v, %v, want [1 +Inf]", got, err)
+	}
+	if err := r.ReadFP8E5M2s(got); err == nil {
+		t.Errorf("ReadFP8E5M2s() at end expected error")
+	}
+}
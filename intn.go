@@ -0,0 +1,198 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrRange is returned when writing a value that does not fit in the width being
+// written.
+var ErrRange = errors.New("endianio: value out of range")
+
+// ReadUintN reads an unsigned integer of size bytes, from 1 to 8, in the byte
+// order of the reader.
+func (r *baseReader) ReadUintN(size int) (uint64, error) {
+	return r.readUintN("ReadUintN", size)
+}
+
+// ReadIntN reads a two's complement signed integer of size bytes, from 1 to 8, in
+// the byte order of the reader, and sign-extends it.
+func (r *baseReader) ReadIntN(size int) (int64, error) {
+	v, err := r.readUintN("ReadIntN", size)
+	return signExtend(v, size), err
+}
+
+// ReadUint24 reads a 24-bit unsigned integer.
+func (r *baseReader) ReadUint24() (uint32, error) {
+	v, err := r.readUintN("ReadUint24", 3)
+	return uint32(v), err
+}
+
+// ReadInt24 reads a 24-bit signed integer.
+func (r *baseReader) ReadInt24() (int32, error) {
+	v, err := r.readUintN("ReadInt24", 3)
+	return int32(signExtend(v, 3)), err
+}
+
+// ReadUint40 reads a 40-bit unsigned integer.
+func (r *baseReader) ReadUint40() (uint64, error) {
+	return r.readUintN("ReadUint40", 5)
+}
+
+// ReadInt40 reads a 40-bit signed integer.
+func (r *baseReader) ReadInt40() (int64, error) {
+	v, err := r.readUintN("ReadInt40", 5)
+	return signExtend(v, 5), err
+}
+
+// ReadUint48 reads a 48-bit unsigned integer.
+func (r *baseReader) ReadUint48() (uint64, error) {
+	return r.readUintN("ReadUint48", 6)
+}
+
+// ReadInt48 reads a 48-bit signed integer.
+func (r *baseReader) ReadInt48() (int64, error) {
+	v, err := r.readUintN("ReadInt48", 6)
+	return signExtend(v, 6), err
+}
+
+func (r *baseReader) readUintN(op string, size int) (uint64, error) {
+	checkWidth(size)
+	var b [8]byte
+	if err := r.readFull(op, b[:size]); err != nil {
+		return 0, err
+	}
+	return uintN(r.order, b[:size]), nil
+}
+
+// WriteUintN writes v as an unsigned integer of size bytes, from 1 to 8, in the
+// byte order of the writer. It fails with ErrRange, writing nothing, if v does
+// not fit in size bytes.
+func (w *baseWriter) WriteUintN(v uint64, size int) (n int, err error) {
+	return w.writeUintN("WriteUintN", v, size, size == 8 || v < 1<<(8*size))
+}
+
+// WriteIntN writes v as a two's complement signed integer of size bytes, from 1
+// to 8, in the byte order of the writer. It fails with ErrRange, writing nothing,
+// if v does not fit in size bytes.
+func (w *baseWriter) WriteIntN(v int64, size int) (n int, err error) {
+	return w.writeUintN("WriteIntN", uint64(v), size, fitsIntN(v, size))
+}
+
+// WriteUint24 writes a 24-bit unsigned integer. It fails with ErrRange if v
+// does not fit in 24 bits.
+func (w *baseWriter) WriteUint24(v uint32) (n int, err error) {
+	return w.writeUintN("WriteUint24", uint64(v), 3, v < 1<<24)
+}
+
+// WriteInt24 writes a 24-bit signed integer. It fails with ErrRange if v does
+// not fit in 24 bits.
+func (w *baseWriter) WriteInt24(v int32) (n int, err error) {
+	return w.writeUintN("WriteInt24", uint64(v), 3, fitsIntN(int64(v), 3))
+}
+
+// WriteUint40 writes a 40-bit unsigned integer. It fails with ErrRange if v
+// does not fit in 40 bits.
+func (w *baseWriter) WriteUint40(v uint64) (n int, err error) {
+	return w.writeUintN("WriteUint40", v, 5, v < 1<<40)
+}
+
+// WriteInt40 writes a 40-bit signed integer. It fails with ErrRange if v does
+// not fit in 40 bits.
+func (w *baseWriter) WriteInt40(v int64) (n int, err error) {
+	return w.writeUintN("WriteInt40", uint64(v), 5, fitsIntN(v, 5))
+}
+
+// WriteUint48 writes a 48-bit unsigned integer. It fails with ErrRange if v
+// does not fit in 48 bits.
+func (w *baseWriter) WriteUint48(v uint64) (n int, err error) {
+	return w.writeUintN("WriteUint48", v, 6, v < 1<<48)
+}
+
+// WriteInt48 writes a 48-bit signed integer. It fails with ErrRange if v does
+// not fit in 48 bits.
+func (w *baseWriter) WriteInt48(v int64) (n int, err error) {
+	return w.writeUintN("WriteInt48", uint64(v), 6, fitsIntN(v, 6))
+}
+
+func (w *baseWriter) writeUintN(op string, v uint64, size int, fits bool) (n int, err error) {
+	checkWidth(size)
+	if !fits {
+		return 0, &OffsetError{Op: op, Offset: w.off, Width: size, Err: fmt.Errorf("%w: %#x in %d bytes", ErrRange, v, size)}
+	}
+	var b [8]byte
+	putUintN(w.order, b[:size], v)
+	return w.write(op, b[:size])
+}
+
+func checkWidth(size int) {
+	if size < 1 || size > 8 {
+		panic(fmt.Sprintf("endianio: invalid integer width %d", size))
+	}
+}
+
+// fitsIntN reports whether v fits in a two's complement integer of size bytes.
+func fitsIntN(v int64, size int) bool {
+	return signExtend(uint64(v), size) == v
+}
+
+// signExtend sign-extends the two's complement integer v of size bytes.
+func signExtend(v uint64, size int) int64 {
+	shift := 64 - 8*uint(size)
+	return int64(v<<shift) >> shift
+}
+
+// plainOrder returns binary.BigEndian or binary.LittleEndian, whichever order
+// matches for 16-bit values. Widths the middle-endian orders do not define use it.
+func plainOrder(order binary.ByteOrder) binary.ByteOrder {
+	if big, _ := isBigEndian(order); big {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// uintN decodes the unsigned integer b, of up to 8 bytes, in the byte order order.
+func uintN(order binary.ByteOrder, b []byte) uint64 {
+	// The widths every order defines use it, so middle-endian orders apply
+	switch len(b) {
+	case 2:
+		return uint64(order.Uint16(b))
+	case 4:
+		return uint64(order.Uint32(b))
+	case 8:
+		return order.Uint64(b)
+	}
+	var buf [8]byte
+	order = plainOrder(order)
+	if order == binary.BigEndian {
+		copy(buf[8-len(b):], b)
+	} else {
+		copy(buf[:], b)
+	}
+	return order.Uint64(buf[:])
+}
+
+// putUintN encodes v into b, of up to 8 bytes, in the byte order order,
+// dropping the high bytes that do not fit.
+func putUintN(order binary.ByteOrder, b []byte, v uint64) {
+	switch len(b) {
+	case 2:
+		order.PutUint16(b, uint16(v))
+		return
+	case 4:
+		order.PutUint32(b, uint32(v))
+		return
+	case 8:
+		order.PutUint64(b, v)
+		return
+	}
+	var buf [8]byte
+	order = plainOrder(order)
+	order.PutUint64(buf[:], v)
+	if order == binary.BigEndian {
+		copy(b, buf[8-len(b):])
+	} else {
+		copy(b, buf[:])
+	}
+}
@@ -0,0 +1,158 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestReadIntN(t *testing.T) {
+	data := []byte{0x81, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	var tests = []struct {
+		name  string
+		size  int
+		wantB uint64 // big-endian
+		wantL uint64 // little-endian
+	}{
+		{"N1", 1, 0x81, 0x81},
+		{"N2", 2, 0x8102, 0x0281},
+		{"N3", 3, 0x810203, 0x030281},
+		{"N4", 4, 0x81020304, 0x04030281},
+		{"N5", 5, 0x8102030405, 0x0504030281},
+		{"N6", 6, 0x810203040506, 0x060504030281},
+		{"N7", 7, 0x81020304050607, 0x07060504030281},
+		{"N8", 8, 0x8102030405060708, 0x0807060504030281},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := NewBigEndianReader(bytes.NewReader(data)).ReadUintN(tt.size); err != nil || got != tt.wantB {
+				t.Errorf("ReadUintN() big-endian got = %#x, %v, want %#x", got, err, tt.wantB)
+			}
+			if got, err := NewLittleEndianReader(bytes.NewReader(data)).ReadUintN(tt.size); err != nil || got != tt.wantL {
+				t.Errorf("ReadUintN() little-endian got = %#x, %v, want %#x", got, err, tt.wantL)
+			}
+
+			// The big-endian values have the top bit set, so they are negative
+			wantSigned := int64(tt.wantB) - int64(1<<(8*tt.size-1))*2
+			if tt.size == 8 {
+				wantSigned = int64(tt.wantB)
+			}
+			if got, err := NewBigEndianReader(bytes.NewReader(data)).ReadIntN(tt.size); err != nil || got != wantSigned {
+				t.Errorf("ReadIntN() got = %v, %v, want %v", got, err, wantSigned)
+			}
+			if got, err := NewLittleEndianReader(bytes.NewReader(data)).ReadIntN(tt.size); tt.size > 1 && (err != nil || got != int64(tt.wantL)) {
+				t.Errorf("ReadIntN() got = %v, %v, want %v", got, err, tt.wantL)
+			}
+		})
+	}
+
+	// Test the fixed width helpers
+	t.Run("FixedWidths", func(t *testing.T) {
+		r := NewBigEndianReader(bytes.NewReader([]byte{0xff, 0xff, 0xfe, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x01}))
+		if got, err := r.ReadInt24(); err != nil || got != -2 {
+			t.Errorf("ReadInt24() got = %v, %v, want %v", got, err, -2)
+		}
+		if got, err := r.ReadInt40(); err != nil || got != 1<<39-1 {
+			t.Errorf("ReadInt40() got = %v, %v, want %v", got, err, int64(1<<39-1))
+		}
+		if got, err := r.ReadInt48(); err != nil || got != -1<<24 {
+			t.Errorf("ReadInt48() got = %v, %v, want %v", got, err, int64(-1<<24))
+		}
+		if got, err := r.ReadUint24(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadUint24() got = %v, %v, want %v", got, err, io.ErrUnexpectedEOF)
+		}
+
+		lr := NewLittleEndianReader(bytes.NewReader([]byte{0x01, 0x02, 0x03, 0x01, 0x02, 0x03, 0x04, 0x05, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06}))
+		if got, err := lr.ReadUint24(); err != nil || got != 0x030201 {
+			t.Errorf("ReadUint24() got = %#x, %v, want %#x", got, err, 0x030201)
+		}
+		if got, err := lr.ReadUint40(); err != nil || got != 0x0504030201 {
+			t.Errorf("ReadUint40() got = %#x, %v, want %#x", got, err, uint64(0x0504030201))
+		}
+		if got, err := lr.ReadUint48(); err != nil || got != 0x060504030201 {
+			t.Errorf("ReadUint48() got = %#x, %v, want %#x", got, err, uint64(0x060504030201))
+		}
+	})
+}
+
+func TestWriteIntN(t *testing.T) {
+	// Test round trips of the extremes of every width
+	t.Run("RoundTrip", func(t *testing.T) {
+		for size := 1; size <= 8; size++ {
+			maxU := uint64(math.MaxUint64) >> (64 - 8*size)
+			minS := int64(-1) << (8*size - 1)
+			maxS := -(minS + 1)
+			buf := &bytes.Buffer{}
+			w := NewLittleEndianWriter(buf)
+			w.WriteUintN(maxU, size)
+			w.WriteIntN(minS, size)
+			w.WriteIntN(maxS, size)
+			w.WriteIntN(-1, size)
+			if buf.Len() != 4*size {
+				t.Fatalf("size %d: wrote %d bytes, want %d", size, buf.Len(), 4*size)
+			}
+
+			r := NewLittleEndianReader(buf)
+			if got, err := r.ReadUintN(size); err != nil || got != maxU {
+				t.Errorf("ReadUintN(%d) got = %#x, %v, want %#x", size, got, err, maxU)
+			}
+			for _, want := range []int64{minS, maxS, -1} {
+				if got, err := r.ReadIntN(size); err != nil || got != want {
+					t.Errorf("ReadIntN(%d) got = %v, %v, want %v", size, got, err, want)
+				}
+			}
+		}
+	})
+
+	// Test the byte layout
+	t.Run("Layout", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		NewBigEndianWriter(buf).WriteUint48(0x010203040506)
+		NewLittleEndianWriter(buf).WriteInt24(-2)
+		NewBigEndianWriter(buf).WriteInt40(-2)
+		NewLittleEndianWriter(buf).WriteUint24(0x010203)
+		NewBigEndianWriter(buf).WriteUint40(0x0102030405)
+		NewLittleEndianWriter(buf).WriteInt48(1)
+		want := []byte{
+			0x01, 0x02, 0x03, 0x04, 0x05, 0x06,
+			0xfe, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xfe,
+			0x03, 0x02, 0x01,
+			0x01, 0x02, 0x03, 0x04, 0x05,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("got = %v, want %v", buf.Bytes(), want)
+		}
+	})
+
+	// Test values out of range
+	t.Run("ErrorCases", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		w := NewBigEndianWriter(buf)
+		if _, err := w.WriteUint24(1 << 24); !errors.Is(err, ErrRange) {
+			t.Errorf("WriteUint24() error = %v, want %v", err, ErrRange)
+		}
+		if _, err := w.WriteInt24(1 << 23); !errors.Is(err, ErrRange) {
+			t.Errorf("WriteInt24() error = %v, want %v", err, ErrRange)
+		}
+		if _, err := w.WriteIntN(-129, 1); !errors.Is(err, ErrRange) {
+			t.Errorf("WriteIntN() error = %v, want %v", err, ErrRange)
+		}
+		if _, err := w.WriteUintN(1<<48, 6); !errors.Is(err, ErrRange) {
+			t.Errorf("WriteUintN() error = %v, want %v", err, ErrRange)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%d bytes written for values out of range", buf.Len())
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("WriteUintN() with size 9 did not panic")
+			}
+		}()
+		w.WriteUintN(0, 9)
+	})
+}
@@ -0,0 +1,281 @@
+package endianio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrReservedOperand is returned when reading a VAX float with the sign bit set
+// and a zero exponent, which VAX hardware traps on.
+var ErrReservedOperand = errors.New("endianio: VAX reserved operand")
+
+// IBM System/360 hexadecimal floats have a sign bit, a 7-bit base 16 exponent
+// biased by 64 and a fraction of 24 (single) or 56 (double) bits without a hidden
+// bit: the value is 0.fraction * 16^(exponent-64).
+
+// IBMFloat32frombits returns the value of the IBM single precision float b. The
+// result is exact, which is why it is a float64: the range of IBM floats is
+// larger than that of float32.
+func IBMFloat32frombits(b uint32) float64 {
+	return ibmFloatFrombits(uint64(b), 24)
+}
+
+// IBMFloat64frombits returns the value of the IBM double precision float b,
+// rounded to nearest even as the 56-bit fraction does not fit in a float64.
+func IBMFloat64frombits(b uint64) float64 {
+	return ibmFloatFrombits(b, 56)
+}
+
+// IBMFloat32bits returns the IBM single precision float closest to f. Values
+// too small for it become zero, and values too large, infinities and NaN fail
+// with ErrRange.
+func IBMFloat32bits(f float64) (uint32, error) {
+	b, err := ibmFloatBits(f, 24)
+	return uint32(b), err
+}
+
+// IBMFloat64bits returns the IBM double precision float for f. Every float64 in
+// its range converts exactly; values too small for it become zero, and values too
+// large, infinities and NaN fail with ErrRange.
+func IBMFloat64bits(f float64) (uint64, error) {
+	return ibmFloatBits(f, 56)
+}
+
+func ibmFloatFrombits(b uint64, fbits uint) float64 {
+	sign := b >> (fbits + 7)
+	exp := int(b>>fbits) & 0x7f
+	frac := b & (1<<fbits - 1)
+	v := math.Ldexp(float64(frac), 4*(exp-64)-int(fbits))
+	if sign != 0 {
+		v = -v
+	}
+	return v
+}
+
+func ibmFloatBits(f float64, fbits uint) (uint64, error) {
+	var sign uint64
+	if math.Signbit(f) {
+		sign = 1 << (fbits + 7)
+	}
+	switch {
+	case f == 0:
+		return sign, nil
+	case math.IsNaN(f) || math.IsInf(f, 0):
+		return 0, fmt.Errorf("%w: %v is not representable as an IBM float", ErrRange, f)
+	}
+
+	// |f| = frac * 2^exp = (frac / 2^shift) * 16^exp16, with frac in [0.5, 1)
+	frac, exp := math.Frexp(math.Abs(f))
+	exp16 := (exp + 3) >> 2
+	shift := uint(4*exp16 - exp)
+	m53 := uint64(frac * (1 << 53))
+	var mant uint64
+	if fbits > 53 {
+		mant = m53 << (fbits - 53) >> shift
+	} else {
+		mant = shiftRoundEven(m53, 53-fbits+shift)
+	}
+	if mant == 1<<fbits {
+		mant >>= 4
+		exp16++
+	}
+
+	biased := exp16 + 64
+	switch {
+	case biased > 0x7f:
+		return 0, fmt.Errorf("%w: %v is too large for an IBM float", ErrRange, f)
+	case biased < 0:
+		// Below the smallest normalized value, denormalize
+		mant = shiftRoundEven(mant, uint(4*-biased))
+		biased = 0
+	}
+	return sign | uint64(biased)<<fbits | mant, nil
+}
+
+// VAX floats have a sign bit, a binary exponent and a fraction with a hidden bit:
+// the value is 0.1fraction * 2^(exponent-bias). F_floating has an 8-bit exponent
+// biased by 128 and a 23-bit fraction, D_floating the same exponent and a 55-bit
+// fraction, and G_floating an 11-bit exponent biased by 1024 and a 52-bit
+// fraction. In memory they are 16-bit words, most significant word first.
+
+type vaxFormat struct {
+	name  string
+	ebits uint
+	fbits uint
+	bias  int
+}
+
+var (
+	vaxF = vaxFormat{"F", 8, 23, 128}
+	vaxD = vaxFormat{"D", 8, 55, 128}
+	vaxG = vaxFormat{"G", 11, 52, 1024}
+)
+
+// frombits returns the value of the VAX float b, rounded to nearest even for
+// D_floating, and to a subnormal float64 for the smallest G_floating values.
+func (f vaxFormat) frombits(b uint64) (float64, error) {
+	sign := b >> (f.ebits + f.fbits)
+	exp := int(b>>f.fbits) & (1<<f.ebits - 1)
+	if exp == 0 {
+		if sign != 0 {
+			return 0, ErrReservedOperand
+		}
+		return 0, nil
+	}
+	mant := 1<<f.fbits | b&(1<<f.fbits-1)
+	v := math.Ldexp(float64(mant), exp-f.bias-1-int(f.fbits))
+	if sign != 0 {
+		v = -v
+	}
+	return v, nil
+}
+
+// bits returns the VAX float closest to v. VAX floats have no negative zero
+// or subnormals, so those become zero; values too large, infinities and NaN
+// fail with ErrRange.
+func (f vaxFormat) bits(v float64) (uint64, error) {
+	switch {
+	case v == 0:
+		return 0, nil
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return 0, fmt.Errorf("%w: %v is not representable as a VAX %s_floating", ErrRange, v, f.name)
+	}
+	var sign uint64
+	if v < 0 {
+		sign = 1 << (f.ebits + f.fbits)
+	}
+
+	// |v| = frac * 2^exp, with frac in [0.5, 1) matching the VAX 0.1fraction
+	frac, exp := math.Frexp(math.Abs(v))
+	m53 := uint64(frac * (1 << 53))
+	var mant uint64
+	if f.fbits+1 >= 53 {
+		mant = m53 << (f.fbits + 1 - 53)
+	} else {
+		mant = shiftRoundEven(m53, 53-f.fbits-1)
+		if mant == 1<<(f.fbits+1) {
+			mant >>= 1
+			exp++
+		}
+	}
+
+	biased := exp + f.bias
+	switch {
+	case biased >= 1<<f.ebits:
+		return 0, fmt.Errorf("%w: %v is too large for a VAX %s_floating", ErrRange, v, f.name)
+	case biased <= 0:
+		return 0, nil
+	}
+	return sign | uint64(biased)<<f.fbits | mant&(1<<f.fbits-1), nil
+}
+
+// ReadIBMFloat32 reads an IBM single precision hexadecimal float, as used by
+// SEG-Y. The value is exact.
+func (r *baseReader) ReadIBMFloat32() (float64, error) {
+	var b [4]byte
+	if err := r.readFull("ReadIBMFloat32", b[:]); err != nil {
+		return 0, err
+	}
+	return IBMFloat32frombits(r.order.Uint32(b[:])), nil
+}
+
+// ReadIBMFloat64 reads an IBM double precision hexadecimal float. The 56-bit
+// fraction is rounded to the 53 bits of a float64.
+func (r *baseReader) ReadIBMFloat64() (float64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadIBMFloat64", b[:]); err != nil {
+		return 0, err
+	}
+	return IBMFloat64frombits(r.order.Uint64(b[:])), nil
+}
+
+// ReadVAXFloatF reads a VAX F_floating value. The value is exact.
+func (r *baseReader) ReadVAXFloatF() (float64, error) {
+	return r.readVAXFloat("ReadVAXFloatF", vaxF, 2)
+}
+
+// ReadVAXFloatD reads a VAX D_floating value. The 56-bit significand is rounded
+// to the 53 bits of a float64.
+func (r *baseReader) ReadVAXFloatD() (float64, error) {
+	return r.readVAXFloat("ReadVAXFloatD", vaxD, 4)
+}
+
+// ReadVAXFloatG reads a VAX G_floating value. The value is exact except for the
+// smallest ones, which are float64 subnormals.
+func (r *baseReader) ReadVAXFloatG() (float64, error) {
+	return r.readVAXFloat("ReadVAXFloatG", vaxG, 4)
+}
+
+// readVAXFloat reads words 16-bit words, each in the byte order of the reader,
+// which is little-endian for data written by a VAX.
+func (r *baseReader) readVAXFloat(op string, f vaxFormat, words int) (float64, error) {
+	off := r.off
+	var b [8]byte
+	if err := r.readFull(op, b[:2*words]); err != nil {
+		return 0, err
+	}
+	var bits uint64
+	for i := 0; i < words; i++ {
+		bits = bits<<16 | uint64(r.order.Uint16(b[2*i:]))
+	}
+	v, err := f.frombits(bits)
+	if err != nil {
+		return 0, &OffsetError{Op: op, Offset: off, Width: 2 * words, N: 2 * words, Err: err}
+	}
+	return v, nil
+}
+
+// WriteIBMFloat32 writes v as an IBM single precision hexadecimal float,
+// rounded to nearest even. It fails with ErrRange if v is too large.
+func (w *baseWriter) WriteIBMFloat32(v float64) (n int, err error) {
+	bits, err := IBMFloat32bits(v)
+	if err != nil {
+		return 0, &OffsetError{Op: "WriteIBMFloat32", Offset: w.off, Width: 4, Err: err}
+	}
+	var b [4]byte
+	w.order.PutUint32(b[:], bits)
+	return w.write("WriteIBMFloat32", b[:])
+}
+
+// WriteIBMFloat64 writes v as an IBM double precision hexadecimal float. It
+// fails with ErrRange if v is too large.
+func (w *baseWriter) WriteIBMFloat64(v float64) (n int, err error) {
+	bits, err := IBMFloat64bits(v)
+	if err != nil {
+		return 0, &OffsetError{Op: "WriteIBMFloat64", Offset: w.off, Width: 8, Err: err}
+	}
+	var b [8]byte
+	w.order.PutUint64(b[:], bits)
+	return w.write("WriteIBMFloat64", b[:])
+}
+
+// WriteVAXFloatF writes v as a VAX F_floating value, rounded to nearest even. It
+// fails with ErrRange if v is too large.
+func (w *baseWriter) WriteVAXFloatF(v float64) (n int, err error) {
+	return w.writeVAXFloat("WriteVAXFloatF", vaxF, 2, v)
+}
+
+// WriteVAXFloatD writes v as a VAX D_floating value. It fails with ErrRange if v
+// is too large.
+func (w *baseWriter) WriteVAXFloatD(v float64) (n int, err error) {
+	return w.writeVAXFloat("WriteVAXFloatD", vaxD, 4, v)
+}
+
+// WriteVAXFloatG writes v as a VAX G_floating value. It fails with ErrRange if v
+// is too large.
+func (w *baseWriter) WriteVAXFloatG(v float64) (n int, err error) {
+	return w.writeVAXFloat("WriteVAXFloatG", vaxG, 4, v)
+}
+
+func (w *baseWriter) writeVAXFloat(op string, f vaxFormat, words int, v float64) (n int, err error) {
+	bits, err := f.bits(v)
+	if err != nil {
+		return 0, &OffsetError{Op: op, Offset: w.off, Width: 2 * words, Err: err}
+	}
+	var b [8]byte
+	for i := 0; i < words; i++ {
+		w.order.PutUint16(b[2*i:], uint16(bits>>(16*(words-1-i))))
+	}
+	return w.write(op, b[:2*words])
+}
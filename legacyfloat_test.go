@@ -0,0 +1,167 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestIBMFloat(t *testing.T) {
+	var tests32 = []struct {
+		name string
+		bits uint32
+		want float64
+	}{
+		{"Zero", 0x00000000, 0},
+		{"One", 0x41100000, 1},
+		{"Hundred", 0x42640000, 100},
+		{"Wikipedia", 0xc276a000, -118.625},
+		{"Tenth", 0x40199999, math.Ldexp(0x199999, -24)}, // 0.1 truncated
+		{"Max", 0x7fffffff, (1 - math.Ldexp(1, -24)) * math.Pow(16, 63)},
+		{"MinNormal", 0x00100000, math.Pow(16, -65)},
+	}
+	for _, tt := range tests32 {
+		t.Run("Single_"+tt.name, func(t *testing.T) {
+			if got := IBMFloat32frombits(tt.bits); got != tt.want {
+				t.Errorf("IBMFloat32frombits(%#08x) got = %v, want %v", tt.bits, got, tt.want)
+			}
+			if got, err := IBMFloat32bits(tt.want); err != nil || got != tt.bits {
+				t.Errorf("IBMFloat32bits(%v) got = %#08x, %v, want %#08x", tt.want, got, err, tt.bits)
+			}
+		})
+	}
+
+	var tests64 = []struct {
+		name string
+		bits uint64
+		want float64
+	}{
+		{"One", 0x4110000000000000, 1},
+		{"Wikipedia", 0xc276a00000000000, -118.625},
+		{"Pi", 0x413243f6a8885a30, math.Pi},
+	}
+	for _, tt := range tests64 {
+		t.Run("Double_"+tt.name, func(t *testing.T) {
+			if got := IBMFloat64frombits(tt.bits); got != tt.want {
+				t.Errorf("IBMFloat64frombits(%#016x) got = %v, want %v", tt.bits, got, tt.want)
+			}
+			if got, err := IBMFloat64bits(tt.want); err != nil || got != tt.bits {
+				t.Errorf("IBMFloat64bits(%v) got = %#016x, %v, want %#016x", tt.want, got, err, tt.bits)
+			}
+		})
+	}
+
+	// Test rounding, underflow and overflow
+	t.Run("Encoding", func(t *testing.T) {
+		if got, _ := IBMFloat32bits(0.1); got != 0x4019999a {
+			t.Errorf("IBMFloat32bits(0.1) got = %#08x, want %#08x", got, 0x4019999a)
+		}
+		if got, _ := IBMFloat32bits(-0.1); got != 0xc019999a {
+			t.Errorf("IBMFloat32bits(-0.1) got = %#08x, want %#08x", got, uint32(0xc019999a))
+		}
+		if got, _ := IBMFloat32bits(math.Pow(16, -66)); got != 0x00010000 {
+			t.Errorf("IBMFloat32bits(16^-66) got = %#08x, want %#08x", got, 0x00010000)
+		}
+		if got, _ := IBMFloat32bits(1e-100); got != 0 {
+			t.Errorf("IBMFloat32bits(1e-100) got = %#08x, want 0", got)
+		}
+		for _, f := range []float64{1e76, math.Inf(1), math.NaN()} {
+			if _, err := IBMFloat32bits(f); !errors.Is(err, ErrRange) {
+				t.Errorf("IBMFloat32bits(%v) error = %v, want %v", f, err, ErrRange)
+			}
+		}
+
+		// Every float64 in range survives a round trip through IBM double
+		for _, f := range []float64{math.Pi, -math.E, 1e-70, 1e70, math.MaxFloat32, 12345.6789} {
+			bits, err := IBMFloat64bits(f)
+			if err != nil || IBMFloat64frombits(bits) != f {
+				t.Errorf("IBMFloat64 round trip of %v got = %v, %v", f, IBMFloat64frombits(bits), err)
+			}
+		}
+	})
+}
+
+func TestVAXFloat(t *testing.T) {
+	var tests = []struct {
+		name   string
+		format string
+		data   []byte // as stored by a VAX
+		want   float64
+	}{
+		{"F_One", "F", []byte{0x80, 0x40, 0x00, 0x00}, 1},
+		{"F_MinusOne", "F", []byte{0x80, 0xc0, 0x00, 0x00}, -1},
+		{"F_Half", "F", []byte{0x00, 0x40, 0x00, 0x00}, 0.5},
+		{"F_Pi", "F", []byte{0x49, 0x41, 0xdb, 0x0f}, float64(float32(math.Pi))},
+		{"F_Zero", "F", []byte{0x00, 0x00, 0x00, 0x00}, 0},
+		{"D_One", "D", []byte{0x80, 0x40, 0, 0, 0, 0, 0, 0}, 1},
+		{"D_Pi", "D", []byte{0x49, 0x41, 0xda, 0x0f, 0x21, 0xa2, 0xc0, 0x68}, math.Pi},
+		{"G_One", "G", []byte{0x10, 0x40, 0, 0, 0, 0, 0, 0}, 1},
+		{"G_Pi", "G", []byte{0x29, 0x40, 0xfb, 0x21, 0x44, 0x54, 0x18, 0x2d}, math.Pi},
+		{"G_MinusTwo", "G", []byte{0x20, 0xc0, 0, 0, 0, 0, 0, 0}, -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLittleEndianReader(bytes.NewReader(tt.data))
+			buf := &bytes.Buffer{}
+			w := NewLittleEndianWriter(buf)
+			var got float64
+			var err error
+			switch tt.format {
+			case "F":
+				got, err = r.ReadVAXFloatF()
+				w.WriteVAXFloatF(tt.want)
+			case "D":
+				got, err = r.ReadVAXFloatD()
+				w.WriteVAXFloatD(tt.want)
+			case "G":
+				got, err = r.ReadVAXFloatG()
+				w.WriteVAXFloatG(tt.want)
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ReadVAXFloat%s() got = %v, %v, want %v", tt.format, got, err, tt.want)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.data) {
+				t.Errorf("WriteVAXFloat%s() got = %#v, want %#v", tt.format, buf.Bytes(), tt.data)
+			}
+		})
+	}
+
+	// Test the byte order of the words
+	t.Run("BigEndianWords", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		NewBigEndianWriter(buf).WriteVAXFloatF(1)
+		if want := []byte{0x40, 0x80, 0x00, 0x00}; !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("WriteVAXFloatF() got = %v, want %v", buf.Bytes(), want)
+		}
+		if got, err := NewBigEndianReader(buf).ReadVAXFloatF(); err != nil || got != 1 {
+			t.Errorf("ReadVAXFloatF() got = %v, %v, want 1", got, err)
+		}
+	})
+
+	// Test reserved operands, overflow and underflow
+	t.Run("ErrorCases", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader([]byte{0x00, 0x80, 0x12, 0x34}))
+		_, err := r.ReadVAXFloatF()
+		var oe *OffsetError
+		if !errors.Is(err, ErrReservedOperand) || !errors.As(err, &oe) {
+			t.Errorf("ReadVAXFloatF() error = %v, want %v", err, ErrReservedOperand)
+		}
+
+		w := NewLittleEndianWriter(&bytes.Buffer{})
+		for _, f := range []float64{1e39, math.Inf(-1), math.NaN()} {
+			if _, err := w.WriteVAXFloatF(f); !errors.Is(err, ErrRange) {
+				t.Errorf("WriteVAXFloatF(%v) error = %v, want %v", f, err, ErrRange)
+			}
+		}
+		if _, err := w.WriteVAXFloatG(math.MaxFloat64); !errors.Is(err, ErrRange) {
+			t.Errorf("WriteVAXFloatG(MaxFloat64) error = %v, want %v", err, ErrRange)
+		}
+		if bits, err := vaxF.bits(1e-40); err != nil || bits != 0 {
+			t.Errorf("F_floating bits(1e-40) got = %#x, %v, want 0", bits, err)
+		}
+		if bits, err := vaxF.bits(math.Copysign(0, -1)); err != nil || bits != 0 {
+			t.Errorf("F_floating bits(-0) got = %#x, %v, want 0", bits, err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package endianio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrLimitExceeded is returned when a read would go past the byte budget of a
+// reader, or allocate more than its per-allocation maximum. See SetLimits.
+var ErrLimitExceeded = errors.New("endianio: limit exceeded")
+
+// Limits bounds the resources a reader may use, for parsing untrusted input.
+type Limits struct {
+	Budget   int64 // most bytes to read from the offset SetLimits is called at, or 0 for no limit
+	MaxAlloc int   // most bytes a single variable-length read may allocate, or 0 for no limit
+}
+
+// SetLimits sets the limits of the reader, replacing any set before. A read
+// that would go past the budget fails with ErrLimitExceeded without reading
+// anything, and so do variable-length reads, such as ReadBytesPrefixed,
+// ReadCString, ReadUTF16 or ReadBigInt, and the slices read by Decode and
+// MakeSlice, whose length would need more than MaxAlloc bytes or more than the
+// rest of the budget. Lengths read from the input are checked before any
+// allocation. Readers created from this one by AsBigEndianReader or
+// AsLittleEndianReader share its limits. It panics if a limit is negative.
+func (r *baseReader) SetLimits(l Limits) {
+	if l.Budget < 0 || l.MaxAlloc < 0 {
+		panic(fmt.Sprintf("endianio: invalid limits %+v", l))
+	}
+	r.limits = l
+	r.end = 0
+	if l.Budget > 0 {
+		r.end = r.off + l.Budget
+	}
+}
+
+// limitedReader is implemented by the readers in this package, so code reading
+// through an EndianReader can apply their limits.
+type limitedReader interface {
+	base() *baseReader
+}
+
+func (r *baseReader) base() *baseReader {
+	return r
+}
+
+// remaining returns the number of bytes left of the budget.
+func (r *baseReader) remaining() int64 {
+	if r.end == 0 {
+		return math.MaxInt64
+	}
+	return r.end - r.off
+}
+
+// checkBudget fails if reading n more bytes would go past the budget.
+func (r *baseReader) checkBudget(n int) error {
+	if int64(n) > r.remaining() {
+		return fmt.Errorf("%w: %d bytes with %d left of the budget of %d", ErrLimitExceeded, n, r.remaining(), r.limits.Budget)
+	}
+	return nil
+}
+
+// checkAlloc fails if the value of the method op, which started at off, needs a
+// buffer of n elements of size bytes larger than MaxAlloc.
+func (r *baseReader) checkAlloc(op string, off int64, n, size int) error {
+	if r.limits.MaxAlloc > 0 && n > r.limits.MaxAlloc/size {
+		w := int(r.off - off)
+		return &OffsetError{Op: op, Offset: off, Width: w, N: w, Err: fmt.Errorf("%w: %d elements of %d bytes, max allocation %d bytes", ErrLimitExceeded, n, size, r.limits.MaxAlloc)}
+	}
+	return nil
+}
+
+// checkLength fails before the value of the method op, which started at off, is
+// allocated if its n elements of size bytes exceed MaxAlloc, or could not all be
+// read within the budget.
+func (r *baseReader) checkLength(op string, off int64, n, size int) error {
+	if err := r.checkAlloc(op, off, n, size); err != nil {
+		return err
+	}
+	return r.checkRemaining(op, off, n, size)
+}
+
+// checkRemaining fails if the n elements of size bytes of the value of the
+// method op, which started at off, could not all be read within the budget.
+func (r *baseReader) checkRemaining(op string, off int64, n, size int) error {
+	if int64(n) > r.remaining()/int64(size) {
+		w := int(r.off - off)
+		return &OffsetError{Op: op, Offset: off, Width: w, N: w, Err: fmt.Errorf("%w: %d elements of %d bytes with %d left of the budget of %d", ErrLimitExceeded, n, size, r.remaining(), r.limits.Budget)}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// hugeReader supplies any number of 0xff bytes, like a hostile upload claiming
+// huge lengths.
+type hugeReader struct{}
+
+func (hugeReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0xff
+	}
+	return len(p), nil
+}
+
+func TestLimitsBudget(t *testing.T) {
+	r := NewBigEndianReader(bytes.NewReader([]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	r.ReadUint8()
+	r.SetLimits(Limits{Budget: 4})
+	if v, err := r.ReadUint16(); err != nil || v != 0x0203 {
+		t.Fatalf("ReadUint16() got = %#x, %v, want 0x0203", v, err)
+	}
+
+	// A read past the budget fails without reading anything
+	_, err := r.ReadUint32()
+	var oe *OffsetError
+	if !errors.Is(err, ErrLimitExceeded) || !errors.As(err, &oe) || oe.Offset != 3 || oe.N != 0 {
+		t.Errorf("ReadUint32() error = %v, want %v at offset 3", err, ErrLimitExceeded)
+	}
+	if r.Offset() != 3 {
+		t.Errorf("Offset() got = %d, want 3", r.Offset())
+	}
+
+	// io.Reader reads are cut short at the budget
+	b := make([]byte, 4)
+	if n, err := r.Read(b); n != 2 || err != nil {
+		t.Errorf("Read() got = %d, %v, want 2, nil", n, err)
+	}
+	if n, err := r.Read(b); n != 0 || !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Read() at the budget got = %d, %v, want 0, %v", n, err, ErrLimitExceeded)
+	}
+	if _, err := r.ReadByte(); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("ReadByte() error = %v, want %v", err, ErrLimitExceeded)
+	}
+	if _, err := r.ReadUint8(); !errors.Is(err, ErrLimitExceeded) || !errors.As(err, &oe) || oe.Offset != 5 {
+		t.Errorf("ReadUint8() error = %v, want %v at offset 5", err, ErrLimitExceeded)
+	}
+
+	// Removing the limits allows reading again
+	r.SetLimits(Limits{})
+	if v, err := r.ReadUint8(); err != nil || v != 6 {
+		t.Errorf("ReadUint8() without limits got = %v, %v, want 6", v, err)
+	}
+}
+
+func TestLimitsVariableLength(t *testing.T) {
+	// The largest length that fits in an int on all platforms
+	maxInt32 := []byte{0xff, 0xff, 0xff, 0x7f}
+	var tests = []struct {
+		name   string
+		limits Limits
+		header []byte // read before the endless 0xff bytes
+		read   func(r *LittleEndianReader) error
+		offset int64
+	}{
+		{"BytesPrefixedMaxAlloc", Limits{MaxAlloc: 1024}, maxInt32, func(r *LittleEndianReader) error {
+			_, err := r.ReadBytesPrefixed(PrefixUint32, -1)
+			return err
+		}, 0},
+		{"BytesPrefixedBudget", Limits{Budget: 1 << 20}, maxInt32, func(r *LittleEndianReader) error {
+			_, err := r.ReadBytesPrefixed(PrefixUint32, -1)
+			return err
+		}, 0},
+		{"StringPrefixedUvarint", Limits{MaxAlloc: 1024}, []byte{0x00, 0x80, 0x80, 0x80, 0x40}, func(r *LittleEndianReader) error {
+			r.ReadUint8()
+			_, err := r.ReadStringPrefixed(PrefixUvarint, -1)
+			return err
+		}, 1},
+		{"CString", Limits{MaxAlloc: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadCString(-1)
+			return err
+		}, 0},
+		{"CStringBudget", Limits{Budget: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadCString(-1)
+			return err
+		}, 0},
+		{"FixedString", Limits{MaxAlloc: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadFixedString(101, 0)
+			return err
+		}, 0},
+		{"UTF16", Limits{MaxAlloc: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadUTF16(51, ReplaceInvalid)
+			return err
+		}, 0},
+		{"UTF16Z", Limits{MaxAlloc: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadUTF16Z(-1, ReplaceInvalid)
+			return err
+		}, 0},
+		{"UTF32", Limits{Budget: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadUTF32(26, ReplaceInvalid)
+			return err
+		}, 0},
+		{"UTF32Z", Limits{MaxAlloc: 100}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadUTF32Z(-1, ReplaceInvalid)
+			return err
+		}, 0},
+		{"BigInt", Limits{MaxAlloc: 16}, nil, func(r *LittleEndianReader) error {
+			_, err := r.ReadBigInt(17, true)
+			return err
+		}, 0},
+		{"FP8s", Limits{MaxAlloc: 16}, nil, func(r *LittleEndianReader) error {
+			return r.ReadFP8E4M3s(make([]float32, 17))
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLittleEndianReader(io.MultiReader(bytes.NewReader(tt.header), hugeReader{}))
+			r.SetLimits(tt.limits)
+			err := tt.read(r)
+			var oe *OffsetError
+			if !errors.Is(err, ErrLimitExceeded) || !errors.As(err, &oe) {
+				t.Fatalf("error = %v, want %v", err, ErrLimitExceeded)
+			}
+			if oe.Offset != tt.offset {
+				t.Errorf("Offset got = %d, want %d", oe.Offset, tt.offset)
+			}
+		})
+	}
+
+	// Values within the limits are read as usual
+	t.Run("WithinLimits", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader([]byte{3, 0, 'a', 'b', 'c', 'd', 'e', 0}))
+		r.SetLimits(Limits{Budget: 8, MaxAlloc: 3})
+		if s, err := r.ReadStringPrefixed(PrefixUint16, -1); err != nil || s != "abc" {
+			t.Errorf("ReadStringPrefixed() got = %q, %v, want %q", s, err, "abc")
+		}
+		if s, err := r.ReadCString(-1); err != nil || s != "de" {
+			t.Errorf("ReadCString() got = %q, %v, want %q", s, err, "de")
+		}
+		if _, err := r.ReadUint8(); !errors.Is(err, ErrLimitExceeded) || errors.Is(err, io.EOF) {
+			t.Errorf("ReadUint8() error = %v, want %v", err, ErrLimitExceeded)
+		}
+	})
+
+	t.Run("InvalidLimits", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("SetLimits() with a negative budget did not panic")
+			}
+		}()
+		NewLittleEndianReader(nil).SetLimits(Limits{Budget: -1})
+	})
+}
+
+func TestLimitsDecode(t *testing.T) {
+	type samples struct {
+		Count  uint32
+		Values []uint64 `endian:"len=Count"`
+	}
+	var tests = []struct {
+		name   string
+		count  uint32
+		limits Limits
+	}{
+		{"MaxAlloc", 0x0fffffff, Limits{Budget: 100, MaxAlloc: 10}},
+		{"Budget", 20, Limits{Budget: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := []byte{byte(tt.count >> 24), byte(tt.count >> 16), byte(tt.count >> 8), byte(tt.count)}
+			r := NewBigEndianReader(io.MultiReader(bytes.NewReader(header), hugeReader{}))
+			r.SetLimits(tt.limits)
+			var v samples
+			err := Decode(r, &v)
+			var oe *OffsetError
+			if !errors.Is(err, ErrLimitExceeded) || !errors.As(err, &oe) || oe.Offset != 4 {
+				t.Errorf("Decode() error = %v, want %v at offset 4", err, ErrLimitExceeded)
+			}
+			if r.Offset() != 4 {
+				t.Errorf("Offset() got = %d, want 4", r.Offset())
+			}
+		})
+	}
+
+	// A byte order override keeps the limits of the reader it is layered on
+	t.Run("Override", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader([]byte{1, 2, 3, 4, 5}))
+		r.SetLimits(Limits{Budget: 3})
+		var v struct {
+			A uint8
+			B uint32 `endian:"big"`
+		}
+		err := Decode(r, &v)
+		var oe *OffsetError
+		if !errors.Is(err, ErrLimitExceeded) || !errors.As(err, &oe) || oe.Offset != 1 || oe.N != 0 {
+			t.Errorf("Decode() error = %v, want %v at offset 1", err, ErrLimitExceeded)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package endianio
+
+import "encoding/binary"
+
+// Byte orders named after where the bytes of the 32-bit value 0xAABBCCDD end up
+// in memory. OrderABCD and OrderDCBA are binary.BigEndian and
+// binary.LittleEndian. OrderCDAB and OrderBADC are middle-endian orders made of
+// 16-bit words, as used by Modbus devices: OrderCDAB stores the least significant
+// word first with each word big-endian, and OrderBADC, the PDP-11 order, stores
+// the most significant word first with each word little-endian. For 64-bit
+// values the word order extends the same way, so OrderCDAB stores 0xAABBCCDDEEFFGGHH
+// as GHEFCDAB and OrderBADC as BADCFEHG.
+//
+// Readers and writers created with NewReader and NewWriter accept all four. The
+// middle-endian orders only define 16, 32 and 64-bit values; the reads and writes
+// of other widths treat OrderCDAB as big-endian and OrderBADC as little-endian.
+var (
+	OrderABCD binary.ByteOrder = binary.BigEndian
+	OrderDCBA binary.ByteOrder = binary.LittleEndian
+	OrderCDAB binary.ByteOrder = wordOrder{name: "OrderCDAB", littleWords: true}
+	OrderBADC binary.ByteOrder = wordOrder{name: "OrderBADC", littleBytes: true}
+)
+
+// wordOrder is a byte order made of 16-bit words.
+type wordOrder struct {
+	name        string
+	littleWords bool // least significant word first
+	littleBytes bool // least significant byte of each word first
+}
+
+func (o wordOrder) Uint16(b []byte) uint16 {
+	return uint16(o.get(b[:2]))
+}
+
+func (o wordOrder) Uint32(b []byte) uint32 {
+	return uint32(o.get(b[:4]))
+}
+
+func (o wordOrder) Uint64(b []byte) uint64 {
+	return o.get(b[:8])
+}
+
+func (o wordOrder) PutUint16(b []byte, v uint16) {
+	o.put(b[:2], uint64(v))
+}
+
+func (o wordOrder) PutUint32(b []byte, v uint32) {
+	o.put(b[:4], uint64(v))
+}
+
+func (o wordOrder) PutUint64(b []byte, v uint64) {
+	o.put(b[:8], v)
+}
+
+func (o wordOrder) String() string {
+	return o.name
+}
+
+func (o wordOrder) get(b []byte) uint64 {
+	var v uint64
+	for i := 0; i < len(b); i += 2 {
+		w := uint64(b[i])<<8 | uint64(b[i+1])
+		if o.littleBytes {
+			w = uint64(b[i+1])<<8 | uint64(b[i])
+		}
+		if o.littleWords {
+			v |= w << (8 * i)
+		} else {
+			v = v<<16 | w
+		}
+	}
+	return v
+}
+
+func (o wordOrder) put(b []byte, v uint64) {
+	for i := 0; i < len(b); i += 2 {
+		shift := 8 * i
+		if !o.littleWords {
+			shift = 8 * (len(b) - 2 - i)
+		}
+		w := uint16(v >> shift)
+		if o.littleBytes {
+			b[i], b[i+1] = byte(w), byte(w>>8)
+		} else {
+			b[i], b[i+1] = byte(w>>8), byte(w)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package endianio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestByteOrders(t *testing.T) {
+	var tests = []struct {
+		name  string
+		order binary.ByteOrder
+		b16   []byte // 0xaabb
+		b32   []byte // 0xaabbccdd
+		b64   []byte // 0x0011223344556677
+	}{
+		{"ABCD", OrderABCD, []byte{0xaa, 0xbb}, []byte{0xaa, 0xbb, 0xcc, 0xdd}, []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77}},
+		{"DCBA", OrderDCBA, []byte{0xbb, 0xaa}, []byte{0xdd, 0xcc, 0xbb, 0xaa}, []byte{0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x00}},
+		{"CDAB", OrderCDAB, []byte{0xaa, 0xbb}, []byte{0xcc, 0xdd, 0xaa, 0xbb}, []byte{0x66, 0x77, 0x44, 0x55, 0x22, 0x33, 0x00, 0x11}},
+		{"BADC", OrderBADC, []byte{0xbb, 0xaa}, []byte{0xbb, 0xaa, 0xdd, 0xcc}, []byte{0x11, 0x00, 0x33, 0x22, 0x55, 0x44, 0x77, 0x66}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.Uint16(tt.b16); got != 0xaabb {
+				t.Errorf("Uint16() got = %#x, want %#x", got, 0xaabb)
+			}
+			if got := tt.order.Uint32(tt.b32); got != 0xaabbccdd {
+				t.Errorf("Uint32() got = %#x, want %#x", got, uint32(0xaabbccdd))
+			}
+			if got := tt.order.Uint64(tt.b64); got != 0x0011223344556677 {
+				t.Errorf("Uint64() got = %#x, want %#x", got, uint64(0x0011223344556677))
+			}
+
+			b := make([]byte, 8)
+			tt.order.PutUint16(b, 0xaabb)
+			if !bytes.Equal(b[:2], tt.b16) {
+				t.Errorf("PutUint16() got = %#v, want %#v", b[:2], tt.b16)
+			}
+			tt.order.PutUint32(b, 0xaabbccdd)
+			if !bytes.Equal(b[:4], tt.b32) {
+				t.Errorf("PutUint32() got = %#v, want %#v", b[:4], tt.b32)
+			}
+			tt.order.PutUint64(b, 0x0011223344556677)
+			if !bytes.Equal(b, tt.b64) {
+				t.Errorf("PutUint64() got = %#v, want %#v", b, tt.b64)
+			}
+		})
+	}
+
+	if got := OrderCDAB.String(); got != "OrderCDAB" {
+		t.Errorf("String() got = %v, want %v", got, "OrderCDAB")
+	}
+}
+
+func TestByteOrderReaderWriter(t *testing.T) {
+	// A Modbus float32 of 123.456 (0x42f6e979) in word-swapped order, followed by
+	// a float64 of 1.5 (0x3ff8000000000000) and a 24-bit integer
+	data := []byte{
+		0xe9, 0x79, 0x42, 0xf6,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3f, 0xf8,
+		0x01, 0x02, 0x03,
+	}
+	r := NewReader(bytes.NewReader(data), OrderCDAB)
+	if got, err := r.ReadFloat32(); err != nil || got != 123.456 {
+		t.Errorf("ReadFloat32() got = %v, %v, want %v", got, err, 123.456)
+	}
+	if got, err := r.ReadFloat64(); err != nil || got != 1.5 {
+		t.Errorf("ReadFloat64() got = %v, %v, want %v", got, err, 1.5)
+	}
+	if got, err := r.(*ByteOrderReader).ReadUint24(); err != nil || got != 0x010203 {
+		t.Errorf("ReadUint24() got = %#x, %v, want %#x", got, err, 0x010203)
+	}
+	if r.Order() != OrderCDAB {
+		t.Errorf("Order() got = %v, want %v", r.Order(), OrderCDAB)
+	}
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf, OrderCDAB)
+	w.WriteFloat32(123.456)
+	w.WriteFloat64(1.5)
+	w.(*ByteOrderWriter).WriteUint24(0x010203)
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("ByteOrderWriter got = %#v, want %#v", buf.Bytes(), data)
+	}
+
+	// Every method round trips in the PDP-11 order
+	buf.Reset()
+	w = NewByteOrderWriter(buf, OrderBADC)
+	w.WriteUint16(0x1234)
+	w.WriteUint32(0x12345678)
+	w.WriteUint64(0x123456789abcdef0)
+	w.WriteInt16(-2)
+	w.WriteInt32(-3)
+	w.WriteInt64(-4)
+	w.WriteFloat32(float32(math.Pi))
+	w.WriteFloat64(math.E)
+	if want := []byte{0x34, 0x12, 0x34, 0x12, 0x78, 0x56}; !bytes.Equal(buf.Bytes()[:6], want) {
+		t.Errorf("ByteOrderWriter got = %#v, want %#v", buf.Bytes()[:6], want)
+	}
+	r = NewByteOrderReader(buf, OrderBADC)
+	u16, _ := r.ReadUint16()
+	u32, _ := r.ReadUint32()
+	u64, _ := r.ReadUint64()
+	i16, _ := r.ReadInt16()
+	i32, _ := r.ReadInt32()
+	i64, _ := r.ReadInt64()
+	f32, _ := r.ReadFloat32()
+	f64, err := r.ReadFloat64()
+	if err != nil || u16 != 0x1234 || u32 != 0x12345678 || u64 != 0x123456789abcdef0 || i16 != -2 || i32 != -3 || i64 != -4 || f32 != float32(math.Pi) || f64 != math.E {
+		t.Errorf("ByteOrderReader got = %#x %#x %#x %d %d %d %v %v, %v", u16, u32, u64, i16, i32, i64, f32, f64, err)
+	}
+}
+
+func TestMiddleEndianWidths(t *testing.T) {
+	// 0xAABBCCDD in OrderCDAB; N-byte and fixed-point reads of 32 bits must
+	// match ReadUint32
+	data := []byte{0xcc, 0xdd, 0xaa, 0xbb}
+	r := NewByteOrderReader(bytes.NewReader(data), OrderCDAB)
+	if got, err := r.ReadUintN(4); err != nil || got != 0xaabbccdd {
+		t.Errorf("ReadUintN(4) got = %#x, %v, want %#x", got, err, uint64(0xaabbccdd))
+	}
+	r = NewByteOrderReader(bytes.NewReader(data), OrderCDAB)
+	want := math.Ldexp(-0x55443323, -31) // 0xAABBCCDD as a signed Q31
+	if got, err := r.ReadFixedPoint(Q31); err != nil || got != want {
+		t.Errorf("ReadFixedPoint(Q31) got = %v, %v, want %v", got, err, want)
+	}
+
+	buf := &bytes.Buffer{}
+	w := NewByteOrderWriter(buf, OrderCDAB)
+	w.WriteUintN(0xaabbccdd, 4)
+	w.WriteFixedPoint(want, Q31, QRoundNearestEven, QReject)
+	w.WriteUintN(0x1122334455667788, 8)
+	if want := []byte{0xcc, 0xdd, 0xaa, 0xbb, 0xcc, 0xdd, 0xaa, 0xbb, 0x77, 0x88, 0x55, 0x66, 0x33, 0x44, 0x11, 0x22}; !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("ByteOrderWriter got = % x, want % x", buf.Bytes(), want)
+	}
+}
@@ -0,0 +1,163 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrTooLong is returned when a length is larger than the allowed maximum, or
+// does not fit in the length prefix.
+var ErrTooLong = errors.New("endianio: length too long")
+
+// Prefix selects the encoding of the length in front of a length-prefixed
+// byte string or string.
+type Prefix int
+
+const (
+	PrefixUint8   Prefix = iota // 8-bit length
+	PrefixUint16                // 16-bit length in the byte order of the reader or writer
+	PrefixUint32                // 32-bit length in the byte order of the reader or writer
+	PrefixUint64                // 64-bit length in the byte order of the reader or writer
+	PrefixUvarint               // protobuf style varint length
+)
+
+// prefixedChunk is the most a prefixed read, or a slice read by Decode,
+// allocates before any data has arrived. Larger values grow the buffer as the
+// data is read, so a hostile length cannot force a large allocation without
+// supplying the bytes.
+const prefixedChunk = 64 * 1024
+
+// ReadBytesPrefixed reads a length with the encoding p, followed by that many
+// bytes. A length larger than maxLen fails with ErrTooLong before any data is read;
+// a negative maxLen means no limit.
+func (r *baseReader) ReadBytesPrefixed(p Prefix, maxLen int) ([]byte, error) {
+	return r.readPrefixed("ReadBytesPrefixed", p, maxLen)
+}
+
+// ReadStringPrefixed reads a length with the encoding p, followed by that many
+// bytes, as a string. The bytes are not checked to be valid UTF-8. A length
+// larger than maxLen fails with ErrTooLong before any data is read; a negative
+// maxLen means no limit.
+func (r *baseReader) ReadStringPrefixed(p Prefix, maxLen int) (string, error) {
+	b, err := r.readPrefixed("ReadStringPrefixed", p, maxLen)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (r *baseReader) readPrefixed(op string, p Prefix, maxLen int) ([]byte, error) {
+	off := r.off
+	n, err := r.readLength(op, p)
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt || (maxLen >= 0 && n > uint64(maxLen)) {
+		w := int(r.off - off)
+		return nil, &OffsetError{Op: op, Offset: off, Width: w, N: w, Err: fmt.Errorf("%w: %d bytes, max %d", ErrTooLong, n, maxLen)}
+	}
+
+	size := int(n)
+	if err := r.checkLength(op, off, size, 1); err != nil {
+		return nil, err
+	}
+	start := r.off
+	b := make([]byte, min(size, prefixedChunk))
+	got := 0
+	for got < size {
+		k, err := r.fill(b[got:])
+		got += k
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, &OffsetError{Op: op, Offset: start, Width: size, N: got, Err: err}
+		}
+		b = append(b, make([]byte, min(size-got, len(b)))...)
+	}
+	return b, nil
+}
+
+// readLength reads a length with the encoding p.
+func (r *baseReader) readLength(op string, p Prefix) (uint64, error) {
+	var b [8]byte
+	switch p {
+	case PrefixUint8:
+		if err := r.readFull(op, b[:1]); err != nil {
+			return 0, err
+		}
+		return uint64(b[0]), nil
+	case PrefixUint16:
+		if err := r.readFull(op, b[:2]); err != nil {
+			return 0, err
+		}
+		return uint64(r.order.Uint16(b[:])), nil
+	case PrefixUint32:
+		if err := r.readFull(op, b[:4]); err != nil {
+			return 0, err
+		}
+		return uint64(r.order.Uint32(b[:])), nil
+	case PrefixUint64:
+		if err := r.readFull(op, b[:8]); err != nil {
+			return 0, err
+		}
+		return r.order.Uint64(b[:]), nil
+	case PrefixUvarint:
+		return r.readUvarint(op)
+	}
+	panic(fmt.Sprintf("endianio: invalid Prefix %d", p))
+}
+
+// WriteBytesPrefixed writes the length of b with the encoding p, followed by b.
+// It fails with ErrTooLong, writing nothing, if the length does not fit in p.
+func (w *baseWriter) WriteBytesPrefixed(p Prefix, b []byte) (n int, err error) {
+	n, err = w.writeLength("WriteBytesPrefixed", p, len(b))
+	if err != nil {
+		return n, err
+	}
+	m, err := w.write("WriteBytesPrefixed", b)
+	return n + m, err
+}
+
+// WriteStringPrefixed writes the length of s with the encoding p, followed by s.
+// It fails with ErrTooLong, writing nothing, if the length does not fit in p.
+func (w *baseWriter) WriteStringPrefixed(p Prefix, s string) (n int, err error) {
+	n, err = w.writeLength("WriteStringPrefixed", p, len(s))
+	if err != nil {
+		return n, err
+	}
+	m, err := w.write("WriteStringPrefixed", []byte(s))
+	return n + m, err
+}
+
+// writeLength writes the length l with the encoding p.
+func (w *baseWriter) writeLength(op string, p Prefix, l int) (n int, err error) {
+	var limit uint64
+	var b [MaxVarintLen64]byte
+	var enc []byte
+	switch p {
+	case PrefixUint8:
+		b[0] = byte(l)
+		limit, enc = math.MaxUint8, b[:1]
+	case PrefixUint16:
+		w.order.PutUint16(b[:], uint16(l))
+		limit, enc = math.MaxUint16, b[:2]
+	case PrefixUint32:
+		w.order.PutUint32(b[:], uint32(l))
+		limit, enc = math.MaxUint32, b[:4]
+	case PrefixUint64:
+		w.order.PutUint64(b[:], uint64(l))
+		limit, enc = math.MaxUint64, b[:8]
+	case PrefixUvarint:
+		limit, enc = math.MaxUint64, binary.AppendUvarint(b[:0], uint64(l))
+	default:
+		panic(fmt.Sprintf("endianio: invalid Prefix %d", p))
+	}
+	if uint64(l) > limit {
+		return 0, &OffsetError{Op: op, Offset: w.off, Width: l, Err: fmt.Errorf("%w: %d bytes, max %d", ErrTooLong, l, limit)}
+	}
+	return w.write(op, enc)
+}
@@ -0,0 +1,144 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWritePrefixed(t *testing.T) {
+	var tests = []struct {
+		name   string
+		prefix Prefix
+		big    bool
+		want   []byte
+	}{
+		{"Uint8", PrefixUint8, true, []byte{0x02, 'h', 'i'}},
+		{"Uint16_BE", PrefixUint16, true, []byte{0x00, 0x02, 'h', 'i'}},
+		{"Uint16_LE", PrefixUint16, false, []byte{0x02, 0x00, 'h', 'i'}},
+		{"Uint32_BE", PrefixUint32, true, []byte{0x00, 0x00, 0x00, 0x02, 'h', 'i'}},
+		{"Uint32_LE", PrefixUint32, false, []byte{0x02, 0x00, 0x00, 0x00, 'h', 'i'}},
+		{"Uint64_BE", PrefixUint64, true, []byte{0, 0, 0, 0, 0, 0, 0, 0x02, 'h', 'i'}},
+		{"Uint64_LE", PrefixUint64, false, []byte{0x02, 0, 0, 0, 0, 0, 0, 0, 'h', 'i'}},
+		{"Uvarint", PrefixUvarint, false, []byte{0x02, 'h', 'i'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			var w interface {
+				WriteStringPrefixed(p Prefix, s string) (int, error)
+				WriteBytesPrefixed(p Prefix, b []byte) (int, error)
+			} = NewLittleEndianWriter(buf)
+			if tt.big {
+				w = NewBigEndianWriter(buf)
+			}
+			n, err := w.WriteStringPrefixed(tt.prefix, "hi")
+			if err != nil {
+				t.Fatalf("WriteStringPrefixed() error = %v", err)
+			}
+			if n != len(tt.want) {
+				t.Errorf("WriteStringPrefixed() n = %v, want %v", n, len(tt.want))
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("WriteStringPrefixed() got = %v, want %v", buf.Bytes(), tt.want)
+			}
+
+			buf.Reset()
+			if _, err := w.WriteBytesPrefixed(tt.prefix, []byte("hi")); err != nil {
+				t.Fatalf("WriteBytesPrefixed() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("WriteBytesPrefixed() got = %v, want %v", buf.Bytes(), tt.want)
+			}
+		})
+	}
+
+	// Test lengths that do not fit in the prefix
+	t.Run("TooLong", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		w := NewBigEndianWriter(buf)
+		if _, err := w.WriteBytesPrefixed(PrefixUint8, make([]byte, 256)); !errors.Is(err, ErrTooLong) {
+			t.Errorf("WriteBytesPrefixed() error = %v, want %v", err, ErrTooLong)
+		}
+		if _, err := w.WriteStringPrefixed(PrefixUint16, strings.Repeat("x", 65536)); !errors.Is(err, ErrTooLong) {
+			t.Errorf("WriteStringPrefixed() error = %v, want %v", err, ErrTooLong)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%d bytes written for a too long value", buf.Len())
+		}
+		if _, err := w.WriteBytesPrefixed(PrefixUint8, make([]byte, 255)); err != nil {
+			t.Errorf("WriteBytesPrefixed() error = %v", err)
+		}
+	})
+}
+
+func TestReadPrefixed(t *testing.T) {
+	// Test round trips for every prefix in both byte orders
+	t.Run("RoundTrip", func(t *testing.T) {
+		values := []string{"", "hello", strings.Repeat("ab", 200), strings.Repeat("z", 70000)}
+		prefixes := []Prefix{PrefixUint8, PrefixUint16, PrefixUint32, PrefixUint64, PrefixUvarint}
+		for _, p := range prefixes {
+			for _, v := range values {
+				if p == PrefixUint8 && len(v) > 255 || p == PrefixUint16 && len(v) > 65535 {
+					continue
+				}
+				be, le := &bytes.Buffer{}, &bytes.Buffer{}
+				NewBigEndianWriter(be).WriteStringPrefixed(p, v)
+				NewLittleEndianWriter(le).WriteBytesPrefixed(p, []byte(v))
+
+				got, err := NewBigEndianReader(be).ReadStringPrefixed(p, -1)
+				if err != nil || got != v {
+					t.Errorf("ReadStringPrefixed(%v) got = %d bytes, %v, want %d bytes", p, len(got), err, len(v))
+				}
+				b, err := NewLittleEndianReader(le).ReadBytesPrefixed(p, len(v))
+				if err != nil || string(b) != v {
+					t.Errorf("ReadBytesPrefixed(%v) got = %d bytes, %v, want %d bytes", p, len(b), err, len(v))
+				}
+			}
+		}
+	})
+
+	// Test the maximum length
+	t.Run("MaxLen", func(t *testing.T) {
+		data := []byte{0x00, 0x05, 'h', 'e', 'l', 'l', 'o'}
+		r := NewBigEndianReader(bytes.NewReader(data))
+		_, err := r.ReadBytesPrefixed(PrefixUint16, 4)
+		if !errors.Is(err, ErrTooLong) {
+			t.Errorf("ReadBytesPrefixed() error = %v, want %v", err, ErrTooLong)
+		}
+		if r.Offset() != 2 {
+			t.Errorf("Offset() got = %v, want %v", r.Offset(), 2)
+		}
+		r = NewBigEndianReader(bytes.NewReader(data))
+		if got, err := r.ReadStringPrefixed(PrefixUint16, 5); err != nil || got != "hello" {
+			t.Errorf("ReadStringPrefixed() got = %q, %v, want %q", got, err, "hello")
+		}
+	})
+
+	// Test truncated input
+	t.Run("ErrorCases", func(t *testing.T) {
+		r := NewLittleEndianReader(bytes.NewReader(nil))
+		if _, err := r.ReadBytesPrefixed(PrefixUint32, -1); !errors.Is(err, io.EOF) {
+			t.Errorf("ReadBytesPrefixed() error = %v, want %v", err, io.EOF)
+		}
+		r = NewLittleEndianReader(bytes.NewReader([]byte{0x05, 0x00}))
+		if _, err := r.ReadBytesPrefixed(PrefixUint32, -1); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadBytesPrefixed() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		r = NewLittleEndianReader(bytes.NewReader([]byte{0x05, 0x00, 0x00, 0x00, 'h', 'e'}))
+		_, err := r.ReadStringPrefixed(PrefixUint32, -1)
+		var oe *OffsetError
+		if !errors.Is(err, io.ErrUnexpectedEOF) || !errors.As(err, &oe) || oe.Offset != 4 || oe.N != 2 || oe.Width != 5 {
+			t.Errorf("ReadStringPrefixed() error = %v, want %v at offset 4 after 2 of 5 bytes", err, io.ErrUnexpectedEOF)
+		}
+
+		// A huge length without the data must not allocate it up front
+		huge := []byte{0xff, 0xff, 0xff, 0x7f, 0x00, 0x00, 0x00, 0x00, 'x'}
+		r = NewLittleEndianReader(bytes.NewReader(huge))
+		if _, err := r.ReadBytesPrefixed(PrefixUint64, -1); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadBytesPrefixed() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package endianio
+
+import (
+	"fmt"
+	"math"
+)
+
+// QFormat describes a binary fixed-point number: a two's complement or unsigned
+// integer of IntBits+FracBits bits, plus a sign bit if Signed, scaled by
+// 2^-FracBits and stored in the low bits of a Width-byte integer. A signed Q15
+// value is QFormat{Width: 2, FracBits: 15, Signed: true}.
+type QFormat struct {
+	Width    int  // size in bytes, from 1 to 8
+	IntBits  int  // integer bits, not counting the sign bit
+	FracBits int  // fraction bits
+	Signed   bool // whether there is a sign bit
+}
+
+// Common Q formats.
+var (
+	Q15      = QFormat{Width: 2, FracBits: 15, Signed: true}
+	Q31      = QFormat{Width: 4, FracBits: 31, Signed: true}
+	Q16Dot16 = QFormat{Width: 4, IntBits: 15, FracBits: 16, Signed: true}
+)
+
+// QRounding selects how encoding to a Q format rounds values between two
+// representable ones.
+type QRounding int
+
+const (
+	QRoundNearestEven QRounding = iota // round to nearest, ties to even
+	QRoundNearestAway                  // round to nearest, ties away from zero
+	QRoundTowardZero                   // truncate
+	QRoundDown                         // round toward negative infinity, like an arithmetic shift
+)
+
+// QOverflow selects what encoding to a Q format does with values beyond its
+// range.
+type QOverflow int
+
+const (
+	QSaturate QOverflow = iota // clamp them, including infinities, to the nearest representable value
+	QReject                    // fail with ErrRange
+)
+
+// bits returns the number of significant bits of f, including the sign bit.
+func (f QFormat) bits() uint {
+	n := f.IntBits + f.FracBits
+	if f.Signed {
+		n++
+	}
+	return uint(n)
+}
+
+func (f QFormat) check() {
+	if f.Width < 1 || f.Width > 8 || f.IntBits < 0 || f.FracBits < 0 || f.bits() < 1 || f.bits() > uint(8*f.Width) {
+		panic(fmt.Sprintf("endianio: invalid Q format %+v", f))
+	}
+}
+
+// Float64 returns the value of the fixed-point number b, whose bits above those
+// of f are ignored. Formats of more than 53 bits are rounded to nearest even.
+func (f QFormat) Float64(b uint64) float64 {
+	f.check()
+	shift := 64 - f.bits()
+	var v float64
+	if f.Signed {
+		v = float64(int64(b<<shift) >> shift)
+	} else {
+		v = float64(b << shift >> shift)
+	}
+	return math.Ldexp(v, -f.FracBits)
+}
+
+// Bits returns the fixed-point number for v, rounded as selected by round, with
+// values out of range handled as selected by overflow. NaN always fails with
+// ErrRange. Signed values are sign-extended to 64 bits.
+func (f QFormat) Bits(v float64, round QRounding, overflow QOverflow) (uint64, error) {
+	f.check()
+	if math.IsNaN(v) {
+		return 0, fmt.Errorf("%w: NaN in Q%d.%d", ErrRange, f.IntBits, f.FracBits)
+	}
+
+	x := math.Ldexp(v, f.FracBits)
+	switch round {
+	case QRoundNearestEven:
+		x = math.RoundToEven(x)
+	case QRoundNearestAway:
+		x = math.Round(x)
+	case QRoundTowardZero:
+		x = math.Trunc(x)
+	case QRoundDown:
+		x = math.Floor(x)
+	default:
+		panic(fmt.Sprintf("endianio: invalid Q rounding %d", round))
+	}
+
+	// The limits are powers of two, so the comparisons are exact
+	n := f.bits()
+	lo, hi := 0.0, math.Ldexp(1, int(n))
+	if f.Signed {
+		lo, hi = -math.Ldexp(1, int(n-1)), math.Ldexp(1, int(n-1))
+	}
+	if x < lo || x >= hi {
+		if overflow != QSaturate {
+			return 0, fmt.Errorf("%w: %v in Q%d.%d", ErrRange, v, f.IntBits, f.FracBits)
+		}
+		if x < lo {
+			return uint64(int64(lo)), nil
+		}
+		if f.Signed {
+			return 1<<(n-1) - 1, nil
+		}
+		return 1<<n - 1, nil
+	}
+	if f.Signed {
+		return uint64(int64(x)), nil
+	}
+	return uint64(x), nil
+}
+
+// ReadFixedPoint reads a fixed-point number in the format f and the byte order
+// of the reader.
+func (r *baseReader) ReadFixedPoint(f QFormat) (float64, error) {
+	f.check()
+	b, err := r.readUintN("ReadFixedPoint", f.Width)
+	if err != nil {
+		return 0, err
+	}
+	return f.Float64(b), nil
+}
+
+// WriteFixedPoint writes v as a fixed-point number in the format f and the byte
+// order of the writer, rounded as selected by round. Values out of range are
+// clamped or fail with ErrRange, writing nothing, as selected by overflow.
+// Signed values are sign-extended to fill the width.
+func (w *baseWriter) WriteFixedPoint(v float64, f QFormat, round QRounding, overflow QOverflow) (n int, err error) {
+	b, err := f.Bits(v, round, overflow)
+	if err != nil {
+		return 0, &OffsetError{Op: "WriteFixedPoint", Offset: w.off, Width: f.Width, Err: err}
+	}
+	return w.writeUintN("WriteFixedPoint", b, f.Width, true)
+}
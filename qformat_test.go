@@ -0,0 +1,161 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestQFormat(t *testing.T) {
+	var tests = []struct {
+		name   string
+		format QFormat
+		bits   uint64
+		want   float64
+	}{
+		{"Q15_Half", Q15, 0x4000, 0.5},
+		{"Q15_MinusOne", Q15, 0xffffffffffff8000, -1},
+		{"Q15_Max", Q15, 0x7fff, 1 - math.Ldexp(1, -15)},
+		{"Q15_MinusLSB", Q15, 0xffffffffffffffff, -math.Ldexp(1, -15)},
+		{"Q31_Quarter", Q31, 0x20000000, 0.25},
+		{"Q31_MinusOne", Q31, 0xffffffff80000000, -1},
+		{"Q16Dot16_Pi", Q16Dot16, 0x0003243f, 0x3243f / 65536.0},
+		{"Q16Dot16_MinusOneAndHalf", Q16Dot16, 0xfffffffffffe8000, -1.5},
+		{"Unsigned_Q8.8", QFormat{Width: 2, IntBits: 8, FracBits: 8}, 0xff80, 255.5},
+		{"Unsigned_12Bit", QFormat{Width: 2, IntBits: 4, FracBits: 8}, 0x0abc, 0xabc / 256.0},
+		{"Signed_12Bit", QFormat{Width: 2, IntBits: 3, FracBits: 8, Signed: true}, 0xfffffffffffffabc, (0xabc - 0x1000) / 256.0},
+		{"Integer", QFormat{Width: 1, IntBits: 7, Signed: true}, 0xffffffffffffff80, -128},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Float64(tt.bits); got != tt.want {
+				t.Errorf("Float64(%#x) got = %v, want %v", tt.bits, got, tt.want)
+			}
+			if got, err := tt.format.Bits(tt.want, QRoundNearestEven, QReject); err != nil || got != tt.bits {
+				t.Errorf("Bits(%v) got = %#x, %v, want %#x", tt.want, got, err, tt.bits)
+			}
+		})
+	}
+
+	// Bits above the format are ignored
+	t.Run("IgnoresHighBits", func(t *testing.T) {
+		f := QFormat{Width: 2, IntBits: 3, FracBits: 8, Signed: true}
+		if got := f.Float64(0xf100); got != 1 {
+			t.Errorf("Float64(0xf100) got = %v, want 1", got)
+		}
+	})
+
+	// Test the rounding modes on ties and between ties
+	t.Run("Rounding", func(t *testing.T) {
+		f := QFormat{Width: 1, IntBits: 3, FracBits: 4, Signed: true}
+		lsb := math.Ldexp(1, -4)
+		var tests = []struct {
+			round QRounding
+			v     float64
+			want  int8
+		}{
+			{QRoundNearestEven, 2.5 * lsb, 2},
+			{QRoundNearestEven, 3.5 * lsb, 4},
+			{QRoundNearestEven, -2.5 * lsb, -2},
+			{QRoundNearestAway, 2.5 * lsb, 3},
+			{QRoundNearestAway, -2.5 * lsb, -3},
+			{QRoundTowardZero, 2.9 * lsb, 2},
+			{QRoundTowardZero, -2.9 * lsb, -2},
+			{QRoundDown, 2.9 * lsb, 2},
+			{QRoundDown, -2.1 * lsb, -3},
+		}
+		for _, tt := range tests {
+			got, err := f.Bits(tt.v, tt.round, QReject)
+			if err != nil || int8(got) != tt.want {
+				t.Errorf("Bits(%v, %d) got = %d, %v, want %d", tt.v, tt.round, int8(got), err, tt.want)
+			}
+		}
+	})
+
+	// Test saturation and range errors
+	t.Run("Overflow", func(t *testing.T) {
+		var tests = []struct {
+			name   string
+			format QFormat
+			v      float64
+			want   uint64
+		}{
+			{"Q15_One", Q15, 1, 0x7fff},
+			{"Q15_RoundsToOne", Q15, 1 - math.Ldexp(1, -17), 0x7fff},
+			{"Q15_BelowMinusOne", Q15, -1.5, 0xffffffffffff8000},
+			{"Q15_Inf", Q15, math.Inf(1), 0x7fff},
+			{"Q15_MinusInf", Q15, math.Inf(-1), 0xffffffffffff8000},
+			{"Unsigned_Negative", QFormat{Width: 1, IntBits: 8}, -1, 0},
+			{"Unsigned_Large", QFormat{Width: 1, IntBits: 8}, 256, 0xff},
+			{"Unsigned64", QFormat{Width: 8, FracBits: 64}, 1, math.MaxUint64},
+			{"Signed64", QFormat{Width: 8, FracBits: 63, Signed: true}, 1, math.MaxInt64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got, err := tt.format.Bits(tt.v, QRoundNearestEven, QSaturate); err != nil || got != tt.want {
+					t.Errorf("Bits(%v, QSaturate) got = %#x, %v, want %#x", tt.v, got, err, tt.want)
+				}
+				if _, err := tt.format.Bits(tt.v, QRoundNearestEven, QReject); !errors.Is(err, ErrRange) {
+					t.Errorf("Bits(%v, QReject) error = %v, want %v", tt.v, err, ErrRange)
+				}
+			})
+		}
+		if _, err := Q15.Bits(math.NaN(), QRoundNearestEven, QSaturate); !errors.Is(err, ErrRange) {
+			t.Errorf("Bits(NaN) error = %v, want %v", err, ErrRange)
+		}
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Float64() with 17 bits in 2 bytes did not panic")
+			}
+		}()
+		QFormat{Width: 2, IntBits: 1, FracBits: 15, Signed: true}.Float64(0)
+	})
+}
+
+func TestReadWriteFixedPoint(t *testing.T) {
+	var tests = []struct {
+		name   string
+		format QFormat
+		v      float64
+		big    []byte
+		little []byte
+	}{
+		{"Q15", Q15, -0.5, []byte{0xc0, 0x00}, []byte{0x00, 0xc0}},
+		{"Q31", Q31, 0.75, []byte{0x60, 0x00, 0x00, 0x00}, []byte{0x00, 0x00, 0x00, 0x60}},
+		{"Q16Dot16", Q16Dot16, -2.25, []byte{0xff, 0xfd, 0xc0, 0x00}, []byte{0x00, 0xc0, 0xfd, 0xff}},
+		{"SignExtended", QFormat{Width: 3, IntBits: 3, FracBits: 8, Signed: true}, -1, []byte{0xff, 0xff, 0x00}, []byte{0x00, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := NewBigEndianReader(bytes.NewReader(tt.big)).ReadFixedPoint(tt.format); err != nil || got != tt.v {
+				t.Errorf("ReadFixedPoint() big-endian got = %v, %v, want %v", got, err, tt.v)
+			}
+			if got, err := NewLittleEndianReader(bytes.NewReader(tt.little)).ReadFixedPoint(tt.format); err != nil || got != tt.v {
+				t.Errorf("ReadFixedPoint() little-endian got = %v, %v, want %v", got, err, tt.v)
+			}
+			buf := &bytes.Buffer{}
+			NewBigEndianWriter(buf).WriteFixedPoint(tt.v, tt.format, QRoundNearestEven, QReject)
+			if !bytes.Equal(buf.Bytes(), tt.big) {
+				t.Errorf("WriteFixedPoint() big-endian got = %v, want %v", buf.Bytes(), tt.big)
+			}
+			buf.Reset()
+			NewLittleEndianWriter(buf).WriteFixedPoint(tt.v, tt.format, QRoundNearestEven, QReject)
+			if !bytes.Equal(buf.Bytes(), tt.little) {
+				t.Errorf("WriteFixedPoint() little-endian got = %v, want %v", buf.Bytes(), tt.little)
+			}
+		})
+	}
+
+	t.Run("ErrRange", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		n, err := NewBigEndianWriter(buf).WriteFixedPoint(2, Q15, QRoundNearestEven, QReject)
+		var oe *OffsetError
+		if n != 0 || buf.Len() != 0 || !errors.Is(err, ErrRange) || !errors.As(err, &oe) {
+			t.Errorf("WriteFixedPoint(2) got = %d, %v, want 0, %v", n, err, ErrRange)
+		}
+	})
+}
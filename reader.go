@@ -3,11 +3,16 @@ package endianio
 
 import (
 	"encoding/binary"
+	"hash"
 	"io"
 	"math"
 )
 
 // EndianReader is an interface that defines methods for reading binary data.
+//
+// All the Read methods follow the same EOF contract: if no bytes at all could be
+// read the error wraps io.EOF, and if the value was only partially read the error
+// wraps io.ErrUnexpectedEOF. Use errors.Is to check for them.
 type EndianReader interface {
 	// ReadUint8 reads a uint8 (byte)
 	ReadUint8() (uint8, error)
@@ -17,24 +22,171 @@ type EndianReader interface {
 	ReadUint32() (uint32, error)
 	// ReadUint64 reads a 64-bit unsigned integer
 	ReadUint64() (uint64, error)
+	// ReadInt8 reads an int8
+	ReadInt8() (int8, error)
+	// ReadInt16 reads a 16-bit signed integer
+	ReadInt16() (int16, error)
+	// ReadInt32 reads a 32-bit signed integer
+	ReadInt32() (int32, error)
+	// ReadInt64 reads a 64-bit signed integer
+	ReadInt64() (int64, error)
 	// ReadFloat32 reads a 32-bit float
 	ReadFloat32() (float32, error)
 	// ReadFloat64 reads a 64-bit float
 	ReadFloat64() (float64, error)
+	// Order returns the byte order of the multi-byte reads
+	Order() binary.ByteOrder
 }
 
 // baseReader provides common functionality for both big-endian and little-endian readers.
 type baseReader struct {
 	io.Reader
+	byteReader io.ByteReader // set when the wrapped reader implements io.ByteReader
+	order      binary.ByteOrder
+	off        int64
+	hash       hash.Hash // set by SetHash
+	limits     Limits    // set by SetLimits
+	end        int64     // offset the budget ends at, or 0 for no budget
 }
 
-// ReadUint8 reads a uint8 (byte)
-func (r *baseReader) ReadUint8() (uint8, error) {
+func newBaseReader(r io.Reader, order binary.ByteOrder) baseReader {
+	br, _ := r.(io.ByteReader)
+	return baseReader{Reader: r, byteReader: br, order: order}
+}
+
+// NewReader creates a reader for the byte order given at run time, which is a
+// *BigEndianReader or a *LittleEndianReader for those orders, and a
+// *ByteOrderReader for any other, such as OrderCDAB.
+func NewReader(r io.Reader, order binary.ByteOrder) EndianReader {
+	switch big, ok := isBigEndian(order); {
+	case !ok:
+		return NewByteOrderReader(r, order)
+	case big:
+		return NewBigEndianReader(r)
+	}
+	return NewLittleEndianReader(r)
+}
+
+// NewNativeEndianReader creates a reader using the byte order of the host.
+func NewNativeEndianReader(r io.Reader) EndianReader {
+	return NewReader(r, binary.NativeEndian)
+}
+
+// orderProbe is the bytes 1 to 8, kept in a variable so probing an order does
+// not allocate.
+var orderProbe = [8]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+// isBigEndian reports whether order puts the most significant byte of 16-bit
+// values first, with ok false if it does not order 32 and 64-bit values the
+// same way, as the middle-endian orders do not.
+func isBigEndian(order binary.ByteOrder) (big, ok bool) {
+	switch order {
+	case binary.BigEndian:
+		return true, true
+	case binary.LittleEndian:
+		return false, true
+	}
+	b := orderProbe[:]
+	if order.Uint16(b) == 0x0102 {
+		return true, order.Uint32(b) == 0x01020304 && order.Uint64(b) == 0x0102030405060708
+	}
+	return false, order.Uint16(b) == 0x0201 && order.Uint32(b) == 0x04030201 && order.Uint64(b) == 0x0807060504030201
+}
+
+// Read implements io.Reader, counting the bytes read.
+func (r *baseReader) Read(p []byte) (n int, err error) {
+	if rem := r.remaining(); int64(len(p)) > rem {
+		if rem == 0 {
+			return 0, &OffsetError{Op: "Read", Offset: r.off, Width: len(p), Err: r.checkBudget(len(p))}
+		}
+		p = p[:rem]
+	}
+	n, err = r.Reader.Read(p)
+	r.consumed(p[:n])
+	return n, err
+}
+
+// ReadByte implements io.ByteReader. Unlike ReadUint8 it returns errors from the
+// wrapped reader, and those for exceeding the limits, as is.
+func (r *baseReader) ReadByte() (byte, error) {
+	if err := r.checkBudget(1); err != nil {
+		return 0, err
+	}
+	if r.byteReader != nil {
+		b, err := r.byteReader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		r.off++
+		if r.hash != nil {
+			r.hash.Write([]byte{b})
+		}
+		return b, nil
+	}
 	var b [1]byte
-	_, err := r.Read(b[:])
+	_, err := r.fill(b[:])
 	return b[0], err
 }
 
+// Order returns the byte order of the reader.
+func (r *baseReader) Order() binary.ByteOrder {
+	return r.order
+}
+
+// Offset returns the number of bytes read so far.
+func (r *baseReader) Offset() int64 {
+	return r.off
+}
+
+// readFull reads exactly len(b) bytes on behalf of the method op, wrapping a
+// failure in an *OffsetError.
+func (r *baseReader) readFull(op string, b []byte) error {
+	off := r.off
+	n, err := r.fill(b)
+	if err != nil {
+		return &OffsetError{Op: op, Offset: off, Width: len(b), N: n, Err: err}
+	}
+	return nil
+}
+
+// fill reads exactly len(b) bytes like io.ReadFull, accounting for the bytes read.
+// It reads nothing if that would go past the budget.
+func (r *baseReader) fill(b []byte) (int, error) {
+	if err := r.checkBudget(len(b)); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(r.Reader, b)
+	r.consumed(b[:n])
+	return n, err
+}
+
+// consumed counts the bytes b read from the wrapped reader, and adds them to the
+// attached hash.
+func (r *baseReader) consumed(b []byte) {
+	r.off += int64(len(b))
+	if r.hash != nil {
+		r.hash.Write(b)
+	}
+}
+
+// ReadUint8 reads a uint8 (byte)
+func (r *baseReader) ReadUint8() (uint8, error) {
+	v, err := r.ReadByte()
+	if err != nil {
+		return 0, &OffsetError{Op: "ReadUint8", Offset: r.off, Width: 1, Err: err}
+	}
+	return v, nil
+}
+
+// ReadInt8 reads an int8
+func (r *baseReader) ReadInt8() (int8, error) {
+	v, err := r.ReadByte()
+	if err != nil {
+		return 0, &OffsetError{Op: "ReadInt8", Offset: r.off, Width: 1, Err: err}
+	}
+	return int8(v), nil
+}
+
 // BigEndianReader reads binary data in big-endian format.
 type BigEndianReader struct {
 	baseReader
@@ -42,14 +194,13 @@ type BigEndianReader struct {
 
 // NewBigEndianReader creates a new BigEndianReader reading from the provided io.Reader.
 func NewBigEndianReader(r io.Reader) *BigEndianReader {
-	return &BigEndianReader{baseReader{r}}
+	return &BigEndianReader{newBaseReader(r, binary.BigEndian)}
 }
 
 // ReadUint16 reads a 16-bit unsigned integer in big-endian format.
 func (r *BigEndianReader) ReadUint16() (uint16, error) {
 	var b [2]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint16", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.BigEndian.Uint16(b[:]), nil
@@ -58,8 +209,7 @@ func (r *BigEndianReader) ReadUint16() (uint16, error) {
 // ReadUint32 reads a 32-bit unsigned integer in big-endian format.
 func (r *BigEndianReader) ReadUint32() (uint32, error) {
 	var b [4]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint32", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.BigEndian.Uint32(b[:]), nil
@@ -68,18 +218,43 @@ func (r *BigEndianReader) ReadUint32() (uint32, error) {
 // ReadUint64 reads a 64-bit unsigned integer in big-endian format.
 func (r *BigEndianReader) ReadUint64() (uint64, error) {
 	var b [8]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint64", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.BigEndian.Uint64(b[:]), nil
 }
 
+// ReadInt16 reads a 16-bit signed integer in big-endian format.
+func (r *BigEndianReader) ReadInt16() (int16, error) {
+	var b [2]byte
+	if err := r.readFull("ReadInt16", b[:]); err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b[:])), nil
+}
+
+// ReadInt32 reads a 32-bit signed integer in big-endian format.
+func (r *BigEndianReader) ReadInt32() (int32, error) {
+	var b [4]byte
+	if err := r.readFull("ReadInt32", b[:]); err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b[:])), nil
+}
+
+// ReadInt64 reads a 64-bit signed integer in big-endian format.
+func (r *BigEndianReader) ReadInt64() (int64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadInt64", b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
 // ReadFloat32 reads a 32-bit float encoded as a 32-bit unsigned integer in big-endian format.
 func (r *BigEndianReader) ReadFloat32() (float32, error) {
 	var b [4]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadFloat32", b[:]); err != nil {
 		return 0, err
 	}
 	return math.Float32frombits(binary.BigEndian.Uint32(b[:])), nil
@@ -88,8 +263,7 @@ func (r *BigEndianReader) ReadFloat32() (float32, error) {
 // ReadFloat64 reads a 64-bit float encoded as a 64-bit unsigned integer in big-endian format.
 func (r *BigEndianReader) ReadFloat64() (float64, error) {
 	var b [8]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadFloat64", b[:]); err != nil {
 		return 0, err
 	}
 	return math.Float64frombits(binary.BigEndian.Uint64(b[:])), nil
@@ -102,14 +276,13 @@ type LittleEndianReader struct {
 
 // NewLittleEndianReader creates a new LittleEndianReader reading from the provided io.Reader.
 func NewLittleEndianReader(r io.Reader) *LittleEndianReader {
-	return &LittleEndianReader{baseReader{r}}
+	return &LittleEndianReader{newBaseReader(r, binary.LittleEndian)}
 }
 
 // ReadUint16 reads a 16-bit unsigned integer in little-endian format.
 func (r *LittleEndianReader) ReadUint16() (uint16, error) {
 	var b [2]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint16", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.LittleEndian.Uint16(b[:]), nil
@@ -118,8 +291,7 @@ func (r *LittleEndianReader) ReadUint16() (uint16, error) {
 // ReadUint32 reads a 32-bit unsigned integer in little-endian format.
 func (r *LittleEndianReader) ReadUint32() (uint32, error) {
 	var b [4]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint32", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.LittleEndian.Uint32(b[:]), nil
@@ -128,18 +300,43 @@ func (r *LittleEndianReader) ReadUint32() (uint32, error) {
 // ReadUint64 reads a 64-bit unsigned integer in little-endian format.
 func (r *LittleEndianReader) ReadUint64() (uint64, error) {
 	var b [8]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadUint64", b[:]); err != nil {
 		return 0, err
 	}
 	return binary.LittleEndian.Uint64(b[:]), nil
 }
 
+// ReadInt16 reads a 16-bit signed integer in little-endian format.
+func (r *LittleEndianReader) ReadInt16() (int16, error) {
+	var b [2]byte
+	if err := r.readFull("ReadInt16", b[:]); err != nil {
+		return 0, err
+	}
+	return int16(binary.LittleEndian.Uint16(b[:])), nil
+}
+
+// ReadInt32 reads a 32-bit signed integer in little-endian format.
+func (r *LittleEndianReader) ReadInt32() (int32, error) {
+	var b [4]byte
+	if err := r.readFull("ReadInt32", b[:]); err != nil {
+		return 0, err
+	}
+	return int32(binary.LittleEndian.Uint32(b[:])), nil
+}
+
+// ReadInt64 reads a 64-bit signed integer in little-endian format.
+func (r *LittleEndianReader) ReadInt64() (int64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadInt64", b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(b[:])), nil
+}
+
 // ReadFloat32 reads a 32-bit float encoded as a 32-bit unsigned integer in little-endian format.
 func (r *LittleEndianReader) ReadFloat32() (float32, error) {
 	var b [4]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadFloat32", b[:]); err != nil {
 		return 0, err
 	}
 	return math.Float32frombits(binary.LittleEndian.Uint32(b[:])), nil
@@ -148,9 +345,92 @@ func (r *LittleEndianReader) ReadFloat32() (float32, error) {
 // ReadFloat64 reads a 64-bit float encoded as a 64-bit unsigned integer in little-endian format.
 func (r *LittleEndianReader) ReadFloat64() (float64, error) {
 	var b [8]byte
-	_, err := io.ReadFull(r, b[:])
-	if err != nil {
+	if err := r.readFull("ReadFloat64", b[:]); err != nil {
 		return 0, err
 	}
 	return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
 }
+
+// ByteOrderReader reads binary data in any byte order, including the
+// middle-endian OrderCDAB and OrderBADC.
+type ByteOrderReader struct {
+	baseReader
+}
+
+// NewByteOrderReader creates a new ByteOrderReader reading from the provided
+// io.Reader in the byte order order.
+func NewByteOrderReader(r io.Reader, order binary.ByteOrder) *ByteOrderReader {
+	return &ByteOrderReader{newBaseReader(r, order)}
+}
+
+// ReadUint16 reads a 16-bit unsigned integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadUint16() (uint16, error) {
+	var b [2]byte
+	if err := r.readFull("ReadUint16", b[:]); err != nil {
+		return 0, err
+	}
+	return r.order.Uint16(b[:]), nil
+}
+
+// ReadUint32 reads a 32-bit unsigned integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadUint32() (uint32, error) {
+	var b [4]byte
+	if err := r.readFull("ReadUint32", b[:]); err != nil {
+		return 0, err
+	}
+	return r.order.Uint32(b[:]), nil
+}
+
+// ReadUint64 reads a 64-bit unsigned integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadUint64() (uint64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadUint64", b[:]); err != nil {
+		return 0, err
+	}
+	return r.order.Uint64(b[:]), nil
+}
+
+// ReadInt16 reads a 16-bit signed integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadInt16() (int16, error) {
+	var b [2]byte
+	if err := r.readFull("ReadInt16", b[:]); err != nil {
+		return 0, err
+	}
+	return int16(r.order.Uint16(b[:])), nil
+}
+
+// ReadInt32 reads a 32-bit signed integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadInt32() (int32, error) {
+	var b [4]byte
+	if err := r.readFull("ReadInt32", b[:]); err != nil {
+		return 0, err
+	}
+	return int32(r.order.Uint32(b[:])), nil
+}
+
+// ReadInt64 reads a 64-bit signed integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadInt64() (int64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadInt64", b[:]); err != nil {
+		return 0, err
+	}
+	return int64(r.order.Uint64(b[:])), nil
+}
+
+// ReadFloat32 reads a 32-bit float encoded as a 32-bit unsigned integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadFloat32() (float32, error) {
+	var b [4]byte
+	if err := r.readFull("ReadFloat32", b[:]); err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(r.order.Uint32(b[:])), nil
+}
+
+// ReadFloat64 reads a 64-bit float encoded as a 64-bit unsigned integer in the byte order of the reader.
+func (r *ByteOrderReader) ReadFloat64() (float64, error) {
+	var b [8]byte
+	if err := r.readFull("ReadFloat64", b[:]); err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(r.order.Uint64(b[:])), nil
+}
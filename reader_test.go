@@ -1,11 +1,16 @@
 package endianio
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"reflect"
 	"testing"
+	"testing/iotest"
 )
 
 type failingReader struct{}
@@ -13,6 +18,21 @@ type failingReader struct{}
 func (fr *failingReader) Read(p []byte) (n int, err error) {
 	return 0, fmt.Errorf("read failed")
 }
+
+// zeroReader returns (0, nil) before every successful read, which io.Reader allows.
+type zeroReader struct {
+	r    io.Reader
+	zero bool
+}
+
+func (zr *zeroReader) Read(p []byte) (n int, err error) {
+	zr.zero = !zr.zero
+	if zr.zero {
+		return 0, nil
+	}
+	return zr.r.Read(p)
+}
+
 func TestLittleEndianReader(t *testing.T) {
 	// Test ReadUint16
 	t.Run("ReadUint16", func(t *testing.T) {
@@ -162,6 +182,121 @@ func TestLittleEndianReader(t *testing.T) {
 			})
 		}
 	})
+	// Test ReadInt8
+	t.Run("ReadInt8", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int8
+		}{
+			{"Int8_1", []byte{0x00}, 0},
+			{"Int8_2", []byte{0x7f}, math.MaxInt8},
+			{"Int8_3", []byte{0x80}, math.MinInt8},
+			{"Int8_4", []byte{0xff}, -1},
+			{"Int8_5", []byte{0xa5}, -91},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt8()
+				if err != nil {
+					t.Errorf("ReadInt8() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt8() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt16
+	t.Run("ReadInt16", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int16
+		}{
+			{"Int16_1", []byte{0x00, 0x00}, 0},
+			{"Int16_2", []byte{0xff, 0xff}, -1},
+			{"Int16_3", []byte{0x34, 0x12}, 0x1234},
+			{"Int16_4", []byte{0xcc, 0xed}, -0x1234},
+			{"Int16_5", []byte{0xff, 0x7f}, math.MaxInt16},
+			{"Int16_6", []byte{0x00, 0x80}, math.MinInt16},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt16()
+				if err != nil {
+					t.Errorf("ReadInt16() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt16() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt32
+	t.Run("ReadInt32", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int32
+		}{
+			{"Int32_1", []byte{0x00, 0x00, 0x00, 0x00}, 0},
+			{"Int32_2", []byte{0xff, 0xff, 0xff, 0xff}, -1},
+			{"Int32_3", []byte{0x78, 0x56, 0x34, 0x12}, 0x12345678},
+			{"Int32_4", []byte{0x88, 0xa9, 0xcb, 0xed}, -0x12345678},
+			{"Int32_5", []byte{0xff, 0xff, 0xff, 0x7f}, math.MaxInt32},
+			{"Int32_6", []byte{0x00, 0x00, 0x00, 0x80}, math.MinInt32},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt32()
+				if err != nil {
+					t.Errorf("ReadInt32() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt32() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt64
+	t.Run("ReadInt64", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int64
+		}{
+			{"Int64_1", []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, 0},
+			{"Int64_2", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, -1},
+			{"Int64_3", []byte{0xf0, 0xde, 0xbc, 0x9a, 0x78, 0x56, 0x34, 0x12}, 0x123456789ABCDEF0},
+			{"Int64_4", []byte{0x10, 0x21, 0x43, 0x65, 0x87, 0xa9, 0xcb, 0xed}, -0x123456789ABCDEF0},
+			{"Int64_5", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, math.MaxInt64},
+			{"Int64_6", []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80}, math.MinInt64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt64()
+				if err != nil {
+					t.Errorf("ReadInt64() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt64() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
 	// Test reading from a failing reader
 	t.Run("FailingReader", func(t *testing.T) {
 		failReader := &failingReader{}
@@ -186,6 +321,11 @@ func TestLittleEndianReader(t *testing.T) {
 		if err == nil {
 			t.Errorf("ReadUint64() expected error for failing reader")
 		}
+
+		_, err = r.ReadInt32()
+		if err == nil {
+			t.Errorf("ReadInt32() expected error for failing reader")
+		}
 	})
 }
 
@@ -367,6 +507,121 @@ func TestBigEndianReader(t *testing.T) {
 			})
 		}
 	})
+	// Test ReadInt8
+	t.Run("ReadInt8", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int8
+		}{
+			{"Int8_1", []byte{0x00}, 0},
+			{"Int8_2", []byte{0x7f}, math.MaxInt8},
+			{"Int8_3", []byte{0x80}, math.MinInt8},
+			{"Int8_4", []byte{0xff}, -1},
+			{"Int8_5", []byte{0xa5}, -91},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt8()
+				if err != nil {
+					t.Errorf("ReadInt8() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt8() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt16
+	t.Run("ReadInt16", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int16
+		}{
+			{"Int16_1", []byte{0x00, 0x00}, 0},
+			{"Int16_2", []byte{0xff, 0xff}, -1},
+			{"Int16_3", []byte{0x12, 0x34}, 0x1234},
+			{"Int16_4", []byte{0xed, 0xcc}, -0x1234},
+			{"Int16_5", []byte{0x7f, 0xff}, math.MaxInt16},
+			{"Int16_6", []byte{0x80, 0x00}, math.MinInt16},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt16()
+				if err != nil {
+					t.Errorf("ReadInt16() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt16() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt32
+	t.Run("ReadInt32", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int32
+		}{
+			{"Int32_1", []byte{0x00, 0x00, 0x00, 0x00}, 0},
+			{"Int32_2", []byte{0xff, 0xff, 0xff, 0xff}, -1},
+			{"Int32_3", []byte{0x12, 0x34, 0x56, 0x78}, 0x12345678},
+			{"Int32_4", []byte{0xed, 0xcb, 0xa9, 0x88}, -0x12345678},
+			{"Int32_5", []byte{0x7f, 0xff, 0xff, 0xff}, math.MaxInt32},
+			{"Int32_6", []byte{0x80, 0x00, 0x00, 0x00}, math.MinInt32},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt32()
+				if err != nil {
+					t.Errorf("ReadInt32() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt32() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadInt64
+	t.Run("ReadInt64", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int64
+		}{
+			{"Int64_1", []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, 0},
+			{"Int64_2", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, -1},
+			{"Int64_3", []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}, 0x123456789ABCDEF0},
+			{"Int64_4", []byte{0xed, 0xcb, 0xa9, 0x87, 0x65, 0x43, 0x21, 0x10}, -0x123456789ABCDEF0},
+			{"Int64_5", []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, math.MaxInt64},
+			{"Int64_6", []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, math.MinInt64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadInt64()
+				if err != nil {
+					t.Errorf("ReadInt64() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadInt64() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
 	// Test reading from a failing reader
 	t.Run("FailingReader", func(t *testing.T) {
 		failReader := &failingReader{}
@@ -391,6 +646,11 @@ func TestBigEndianReader(t *testing.T) {
 		if err == nil {
 			t.Errorf("ReadUint64() expected error for failing reader")
 		}
+
+		_, err = r.ReadInt32()
+		if err == nil {
+			t.Errorf("ReadInt32() expected error for failing reader")
+		}
 	})
 }
 
@@ -407,6 +667,123 @@ var (
 	littleEndianUint64Data = []byte{0xF0, 0xDE, 0xBC, 0x9A, 0x78, 0x56, 0x34, 0x12} // 0x123456789ABCDEF0
 )
 
+func TestNewReader(t *testing.T) {
+	data := []byte{0x12, 0x34}
+	var tests = []struct {
+		name  string
+		order binary.ByteOrder
+		want  uint16
+	}{
+		{"BigEndian", binary.BigEndian, 0x1234},
+		{"LittleEndian", binary.LittleEndian, 0x3412},
+		{"NativeEndian", binary.NativeEndian, binary.NativeEndian.Uint16(data)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(bytes.NewReader(data), tt.order)
+			got, err := r.ReadUint16()
+			if err != nil {
+				t.Fatalf("ReadUint16() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadUint16() got = %#x, want %#x", got, tt.want)
+			}
+			if r.Order().Uint16(data) != tt.want {
+				t.Errorf("Order() got = %v, want %v", r.Order(), tt.order)
+			}
+		})
+	}
+
+	if _, ok := NewReader(nil, binary.BigEndian).(*BigEndianReader); !ok {
+		t.Errorf("NewReader(BigEndian) is not a *BigEndianReader")
+	}
+	if _, ok := NewReader(nil, binary.LittleEndian).(*LittleEndianReader); !ok {
+		t.Errorf("NewReader(LittleEndian) is not a *LittleEndianReader")
+	}
+	if got := NewNativeEndianReader(nil).Order().Uint16(data); got != binary.NativeEndian.Uint16(data) {
+		t.Errorf("NewNativeEndianReader() reads %#x, want %#x", got, binary.NativeEndian.Uint16(data))
+	}
+	if _, ok := NewReader(nil, OrderBADC).(*ByteOrderReader); !ok {
+		t.Errorf("NewReader(OrderBADC) is not a *ByteOrderReader")
+	}
+}
+
+func TestReaderEOFContract(t *testing.T) {
+	readers := []struct {
+		name string
+		new  func(data []byte) io.Reader
+	}{
+		{"bytes.Reader", func(data []byte) io.Reader { return bytes.NewReader(data) }},
+		{"bufio.Reader", func(data []byte) io.Reader { return bufio.NewReader(bytes.NewReader(data)) }},
+		{"OneByteReader", func(data []byte) io.Reader { return iotest.OneByteReader(bytes.NewReader(data)) }},
+		{"DataErrReader", func(data []byte) io.Reader { return iotest.DataErrReader(bytes.NewReader(data)) }},
+		{"zeroReader", func(data []byte) io.Reader { return &zeroReader{r: iotest.OneByteReader(bytes.NewReader(data))} }},
+	}
+	var reads = []struct {
+		name  string
+		width int
+		read  func(r EndianReader) (any, error)
+	}{
+		{"ReadUint8", 1, func(r EndianReader) (any, error) { return r.ReadUint8() }},
+		{"ReadInt8", 1, func(r EndianReader) (any, error) { return r.ReadInt8() }},
+		{"ReadUint16", 2, func(r EndianReader) (any, error) { return r.ReadUint16() }},
+		{"ReadInt16", 2, func(r EndianReader) (any, error) { return r.ReadInt16() }},
+		{"ReadUint32", 4, func(r EndianReader) (any, error) { return r.ReadUint32() }},
+		{"ReadInt32", 4, func(r EndianReader) (any, error) { return r.ReadInt32() }},
+		{"ReadFloat32", 4, func(r EndianReader) (any, error) { return r.ReadFloat32() }},
+		{"ReadUint64", 8, func(r EndianReader) (any, error) { return r.ReadUint64() }},
+		{"ReadInt64", 8, func(r EndianReader) (any, error) { return r.ReadInt64() }},
+		{"ReadFloat64", 8, func(r EndianReader) (any, error) { return r.ReadFloat64() }},
+	}
+	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+	for _, rd := range readers {
+		for _, tt := range reads {
+			t.Run(rd.name+"/"+tt.name, func(t *testing.T) {
+				// A complete value followed by a clean EOF
+				r := NewBigEndianReader(rd.new(data[:tt.width]))
+				if _, err := tt.read(r); err != nil {
+					t.Fatalf("%s() error = %v", tt.name, err)
+				}
+				if r.Offset() != int64(tt.width) {
+					t.Errorf("Offset() got = %v, want %v", r.Offset(), tt.width)
+				}
+				v, err := tt.read(r)
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("%s() at end error = %v, want %v", tt.name, err, io.EOF)
+				}
+				if v != reflect.Zero(reflect.TypeOf(v)).Interface() {
+					t.Errorf("%s() at end got = %v, want zero", tt.name, v)
+				}
+
+				// A truncated value
+				if tt.width > 1 {
+					r = NewBigEndianReader(rd.new(data[:tt.width-1]))
+					_, err = tt.read(r)
+					if !errors.Is(err, io.ErrUnexpectedEOF) {
+						t.Errorf("%s() truncated error = %v, want %v", tt.name, err, io.ErrUnexpectedEOF)
+					}
+					var oe *OffsetError
+					if !errors.As(err, &oe) || oe.N != tt.width-1 {
+						t.Errorf("%s() truncated error = %#v, want N = %d", tt.name, err, tt.width-1)
+					}
+				}
+			})
+		}
+	}
+
+	// Zero-byte reads must not produce a bogus value
+	t.Run("ZeroByteReads", func(t *testing.T) {
+		r := NewLittleEndianReader(&zeroReader{r: bytes.NewReader([]byte{0xab, 0xcd})})
+		if got, err := r.ReadUint8(); err != nil || got != 0xab {
+			t.Errorf("ReadUint8() got = %v, %v, want %v", got, err, 0xab)
+		}
+		if got, err := r.ReadInt8(); err != nil || got != -0x33 {
+			t.Errorf("ReadInt8() got = %v, %v, want %v", got, err, -0x33)
+		}
+	})
+}
+
 func BenchmarkBigEndianReader_ReadUint16(b *testing.B) {
 	br := bytes.NewReader(bigEndianUint16Data)
 	r := NewBigEndianReader(br)
@@ -0,0 +1,219 @@
+package endianio
+
+import "fmt"
+
+// StickyError is the error reported by StickyReader.Err and StickyWriter.Err.
+// It records which call failed first.
+type StickyError struct {
+	Field  int   // zero based index of the read or write call that failed
+	Offset int64 // byte offset where the failed field started, see StickyReader and StickyWriter
+	Err    error // the underlying error
+}
+
+func (e *StickyError) Error() string {
+	return fmt.Sprintf("endianio: field %d at offset %d: %v", e.Field, e.Offset, e.Err)
+}
+
+func (e *StickyError) Unwrap() error {
+	return e.Err
+}
+
+// StickyReader wraps an EndianReader, such as a BigEndianReader or LittleEndianReader,
+// so its read methods return just the value. The first error is latched; after
+// it, all reads do nothing and return zero. Check Err once when done:
+//
+//	s := NewStickyReader(NewBigEndianReader(r))
+//	h.Magic = s.ReadUint32()
+//	h.Version = s.ReadUint16()
+//	h.Length = s.ReadUint64()
+//	if err := s.Err(); err != nil {
+//		return err
+//	}
+//
+// The offsets in errors are those of the wrapped reader if it has an
+// Offset() int64 method, as the readers in this package do, and otherwise
+// count from where the StickyReader was created.
+type StickyReader struct {
+	r     EndianReader
+	o     offsetter // r, if it reports its offset
+	err   *StickyError
+	field int
+	off   int64
+}
+
+// NewStickyReader creates a new StickyReader reading from the provided EndianReader.
+func NewStickyReader(r EndianReader) *StickyReader {
+	o, _ := r.(offsetter)
+	return &StickyReader{r: r, o: o}
+}
+
+// Err returns the first error encountered as a *StickyError, or nil.
+func (s *StickyReader) Err() error {
+	if s.err == nil {
+		return nil
+	}
+	return s.err
+}
+
+func stickyRead[T any](s *StickyReader, width int, read func() (T, error)) T {
+	var zero T
+	if s.err != nil {
+		return zero
+	}
+	if s.o != nil {
+		s.off = s.o.Offset()
+	}
+	v, err := read()
+	if err != nil {
+		s.err = &StickyError{Field: s.field, Offset: s.off, Err: err}
+		return zero
+	}
+	s.field++
+	s.off += int64(width)
+	return v
+}
+
+// ReadUint8 reads a uint8 (byte)
+func (s *StickyReader) ReadUint8() uint8 {
+	return stickyRead(s, 1, s.r.ReadUint8)
+}
+
+// ReadUint16 reads a 16-bit unsigned integer
+func (s *StickyReader) ReadUint16() uint16 {
+	return stickyRead(s, 2, s.r.ReadUint16)
+}
+
+// ReadUint32 reads a 32-bit unsigned integer
+func (s *StickyReader) ReadUint32() uint32 {
+	return stickyRead(s, 4, s.r.ReadUint32)
+}
+
+// ReadUint64 reads a 64-bit unsigned integer
+func (s *StickyReader) ReadUint64() uint64 {
+	return stickyRead(s, 8, s.r.ReadUint64)
+}
+
+// ReadInt8 reads an int8
+func (s *StickyReader) ReadInt8() int8 {
+	return stickyRead(s, 1, s.r.ReadInt8)
+}
+
+// ReadInt16 reads a 16-bit signed integer
+func (s *StickyReader) ReadInt16() int16 {
+	return stickyRead(s, 2, s.r.ReadInt16)
+}
+
+// ReadInt32 reads a 32-bit signed integer
+func (s *StickyReader) ReadInt32() int32 {
+	return stickyRead(s, 4, s.r.ReadInt32)
+}
+
+// ReadInt64 reads a 64-bit signed integer
+func (s *StickyReader) ReadInt64() int64 {
+	return stickyRead(s, 8, s.r.ReadInt64)
+}
+
+// ReadFloat32 reads a 32-bit float
+func (s *StickyReader) ReadFloat32() float32 {
+	return stickyRead(s, 4, s.r.ReadFloat32)
+}
+
+// ReadFloat64 reads a 64-bit float
+func (s *StickyReader) ReadFloat64() float64 {
+	return stickyRead(s, 8, s.r.ReadFloat64)
+}
+
+// StickyWriter wraps an EndianWriter, such as a BigEndianWriter or LittleEndianWriter,
+// so its write methods return nothing. The first error is latched; after it,
+// all writes do nothing. Check Err once when done.
+//
+// The offsets in errors are those of the wrapped writer if it has an
+// Offset() int64 method, as the writers in this package do, and otherwise
+// count from where the StickyWriter was created.
+type StickyWriter struct {
+	w     EndianWriter
+	o     offsetter // w, if it reports its offset
+	err   *StickyError
+	field int
+	off   int64
+}
+
+// NewStickyWriter creates a new StickyWriter writing to the provided EndianWriter.
+func NewStickyWriter(w EndianWriter) *StickyWriter {
+	o, _ := w.(offsetter)
+	return &StickyWriter{w: w, o: o}
+}
+
+// Err returns the first error encountered as a *StickyError, or nil.
+func (s *StickyWriter) Err() error {
+	if s.err == nil {
+		return nil
+	}
+	return s.err
+}
+
+func stickyWrite[T any](s *StickyWriter, v T, write func(T) (int, error)) {
+	if s.err != nil {
+		return
+	}
+	if s.o != nil {
+		s.off = s.o.Offset()
+	}
+	n, err := write(v)
+	if err != nil {
+		s.err = &StickyError{Field: s.field, Offset: s.off, Err: err}
+		return
+	}
+	s.field++
+	s.off += int64(n)
+}
+
+// WriteUint8 writes a uint8 (byte)
+func (s *StickyWriter) WriteUint8(v uint8) {
+	stickyWrite(s, v, s.w.WriteUint8)
+}
+
+// WriteUint16 writes a 16-bit unsigned integer
+func (s *StickyWriter) WriteUint16(v uint16) {
+	stickyWrite(s, v, s.w.WriteUint16)
+}
+
+// WriteUint32 writes a 32-bit unsigned integer
+func (s *StickyWriter) WriteUint32(v uint32) {
+	stickyWrite(s, v, s.w.WriteUint32)
+}
+
+// WriteUint64 writes a 64-bit unsigned integer
+func (s *StickyWriter) WriteUint64(v uint64) {
+	stickyWrite(s, v, s.w.WriteUint64)
+}
+
+// WriteInt8 writes an int8
+func (s *StickyWriter) WriteInt8(v int8) {
+	stickyWrite(s, v, s.w.WriteInt8)
+}
+
+// WriteInt16 writes a 16-bit signed integer
+func (s *StickyWriter) WriteInt16(v int16) {
+	stickyWrite(s, v, s.w.WriteInt16)
+}
+
+// WriteInt32 writes a 32-bit signed integer
+func (s *StickyWriter) WriteInt32(v int32) {
+	stickyWrite(s, v, s.w.WriteInt32)
+}
+
+// WriteInt64 writes a 64-bit signed integer
+func (s *StickyWriter) WriteInt64(v int64) {
+	stickyWrite(s, v, s.w.WriteInt64)
+}
+
+// WriteFloat32 writes a 32-bit float
+func (s *StickyWriter) WriteFloat32(v float32) {
+	stickyWrite(s, v, s.w.WriteFloat32)
+}
+
+// WriteFloat64 writes a 64-bit float
+func (s *StickyWriter) WriteFloat64(v float64) {
+	stickyWrite(s, v, s.w.WriteFloat64)
+}
@@ -0,0 +1,158 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestStickyReader(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		data := []byte{
+			0xA5, 0x12, 0x34, 0x12, 0x34, 0x56, 0x78,
+			0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE, 0xF0,
+			0xFF, 0xFF, 0xFE, 0xFF, 0xFF, 0xFF, 0xFD,
+			0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFC,
+			0x3d, 0xcc, 0xcc, 0xcd,
+			0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a,
+		}
+		s := NewStickyReader(NewBigEndianReader(bytes.NewReader(data)))
+		if got := s.ReadUint8(); got != 0xA5 {
+			t.Errorf("ReadUint8() got = %v, want 0xA5", got)
+		}
+		if got := s.ReadUint16(); got != 0x1234 {
+			t.Errorf("ReadUint16() got = %v, want 0x1234", got)
+		}
+		if got := s.ReadUint32(); got != 0x12345678 {
+			t.Errorf("ReadUint32() got = %v, want 0x12345678", got)
+		}
+		if got := s.ReadUint64(); got != 0x123456789ABCDEF0 {
+			t.Errorf("ReadUint64() got = %v, want 0x123456789ABCDEF0", got)
+		}
+		if got := s.ReadInt8(); got != -1 {
+			t.Errorf("ReadInt8() got = %v, want -1", got)
+		}
+		if got := s.ReadInt16(); got != -2 {
+			t.Errorf("ReadInt16() got = %v, want -2", got)
+		}
+		if got := s.ReadInt32(); got != -3 {
+			t.Errorf("ReadInt32() got = %v, want -3", got)
+		}
+		if got := s.ReadInt64(); got != -4 {
+			t.Errorf("ReadInt64() got = %v, want -4", got)
+		}
+		if got := s.ReadFloat32(); got != 0.1 {
+			t.Errorf("ReadFloat32() got = %v, want 0.1", got)
+		}
+		if got := s.ReadFloat64(); got != 0.1 {
+			t.Errorf("ReadFloat64() got = %v, want 0.1", got)
+		}
+		if err := s.Err(); err != nil {
+			t.Errorf("Err() = %v, want nil", err)
+		}
+	})
+
+	t.Run("FirstErrorLatched", func(t *testing.T) {
+		data := []byte{0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC}
+		r := bytes.NewReader(data)
+		s := NewStickyReader(NewLittleEndianReader(r))
+		s.ReadUint16()
+		s.ReadUint8()
+		if got := s.ReadUint32(); got != 0 {
+			t.Errorf("ReadUint32() got = %v, want 0 after error", got)
+		}
+		if got := s.ReadUint8(); got != 0 {
+			t.Errorf("ReadUint8() got = %v, want 0 after error", got)
+		}
+		if r.Len() != 0 {
+			t.Errorf("reads continued after the error, %d bytes left", r.Len())
+		}
+
+		var se *StickyError
+		if err := s.Err(); !errors.As(err, &se) {
+			t.Fatalf("Err() = %v, want a *StickyError", err)
+		}
+		if se.Field != 2 || se.Offset != 3 {
+			t.Errorf("Err() field = %d, offset = %d, want 2, 3", se.Field, se.Offset)
+		}
+		if !errors.Is(s.Err(), io.ErrUnexpectedEOF) {
+			t.Errorf("Err() = %v, want %v", s.Err(), io.ErrUnexpectedEOF)
+		}
+	})
+
+	t.Run("WrappedAtOffset", func(t *testing.T) {
+		r := NewBigEndianReader(bytes.NewReader([]byte{1, 2, 3, 4, 5}))
+		r.ReadUint32()
+		s := NewStickyReader(r)
+		s.ReadUint16()
+
+		var se *StickyError
+		if err := s.Err(); !errors.As(err, &se) {
+			t.Fatalf("Err() = %v, want a *StickyError", err)
+		}
+		if se.Field != 0 || se.Offset != 4 {
+			t.Errorf("Err() field = %d, offset = %d, want 0, 4", se.Field, se.Offset)
+		}
+	})
+}
+
+func TestStickyWriter(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		s := NewStickyWriter(NewLittleEndianWriter(buf))
+		s.WriteUint8(0xA5)
+		s.WriteUint16(0x1234)
+		s.WriteUint32(0x12345678)
+		s.WriteUint64(0x123456789ABCDEF0)
+		s.WriteInt8(-1)
+		s.WriteInt16(-2)
+		s.WriteInt32(-3)
+		s.WriteInt64(-4)
+		s.WriteFloat32(-0.1)
+		s.WriteFloat64(-0.1)
+		if err := s.Err(); err != nil {
+			t.Fatalf("Err() = %v, want nil", err)
+		}
+		want := []byte{
+			0xA5, 0x34, 0x12, 0x78, 0x56, 0x34, 0x12,
+			0xF0, 0xDE, 0xBC, 0x9A, 0x78, 0x56, 0x34, 0x12,
+			0xFF, 0xFE, 0xFF, 0xFD, 0xFF, 0xFF, 0xFF,
+			0xFC, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+			0xcd, 0xcc, 0xcc, 0xbd,
+			0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0xbf,
+		}
+		if got := buf.Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("got = % X, want % X", got, want)
+		}
+	})
+
+	t.Run("FailingWriter", func(t *testing.T) {
+		s := NewStickyWriter(NewBigEndianWriter(&failingWriter{}))
+		s.WriteUint32(0x12345678)
+		s.WriteUint16(0x1234)
+
+		var se *StickyError
+		if err := s.Err(); !errors.As(err, &se) {
+			t.Fatalf("Err() = %v, want a *StickyError", err)
+		}
+		if se.Field != 0 || se.Offset != 0 {
+			t.Errorf("Err() field = %d, offset = %d, want 0, 0", se.Field, se.Offset)
+		}
+	})
+	t.Run("WrappedAtOffset", func(t *testing.T) {
+		w := NewLittleEndianWriter(&shortWriter{n: 6})
+		w.WriteUint32(1)
+		s := NewStickyWriter(w)
+		s.WriteUint16(2)
+		s.WriteUint32(3)
+
+		var se *StickyError
+		if err := s.Err(); !errors.As(err, &se) {
+			t.Fatalf("Err() = %v, want a *StickyError", err)
+		}
+		if se.Field != 1 || se.Offset != 6 {
+			t.Errorf("Err() field = %d, offset = %d, want 1, 6", se.Field, se.Offset)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrUnterminated is returned by ReadCString when the input ends before the
+	// terminating NUL. The error also wraps io.ErrUnexpectedEOF.
+	ErrUnterminated = errors.New("endianio: unterminated string")
+	// ErrNUL is returned by WriteCString for a string containing a NUL byte.
+	ErrNUL = errors.New("endianio: string contains a NUL byte")
+)
+
+// ReadCString reads a NUL-terminated string, and returns it without the NUL. If
+// no NUL is found within maxLen bytes the string fails with ErrTooLong, after
+// consuming maxLen+1 bytes; a negative maxLen means no limit.
+func (r *baseReader) ReadCString(maxLen int) (string, error) {
+	off := r.off
+	var b []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(b) > 0 {
+				err = fmt.Errorf("%w: %w", ErrUnterminated, io.ErrUnexpectedEOF)
+			}
+			return "", &OffsetError{Op: "ReadCString", Offset: off, Width: len(b) + 1, N: len(b), Err: err}
+		}
+		if c == 0 {
+			return string(b), nil
+		}
+		if maxLen >= 0 && len(b) == maxLen {
+			return "", &OffsetError{Op: "ReadCString", Offset: off, Width: len(b) + 1, N: len(b) + 1, Err: fmt.Errorf("%w: no NUL within %d bytes", ErrTooLong, maxLen)}
+		}
+		if err := r.checkAlloc("ReadCString", off, len(b)+1, 1); err != nil {
+			return "", err
+		}
+		b = append(b, c)
+	}
+}
+
+// ReadFixedString reads a string stored in a field of size bytes. With a pad of 0
+// the string ends at the first NUL, as in C; otherwise trailing pad bytes, such
+// as spaces, are removed.
+func (r *baseReader) ReadFixedString(size int, pad byte) (string, error) {
+	if err := r.checkLength("ReadFixedString", r.off, size, 1); err != nil {
+		return "", err
+	}
+	b := make([]byte, size)
+	if err := r.readFull("ReadFixedString", b); err != nil {
+		return "", err
+	}
+	if pad == 0 {
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return string(b), nil
+	}
+	for len(b) > 0 && b[len(b)-1] == pad {
+		b = b[:len(b)-1]
+	}
+	return string(b), nil
+}
+
+// WriteCString writes s followed by a NUL. It fails with ErrNUL, writing
+// nothing, if s contains a NUL.
+func (w *baseWriter) WriteCString(s string) (n int, err error) {
+	if strings.IndexByte(s, 0) >= 0 {
+		return 0, &OffsetError{Op: "WriteCString", Offset: w.off, Width: len(s) + 1, Err: ErrNUL}
+	}
+	b := make([]byte, len(s)+1)
+	copy(b, s)
+	return w.write("WriteCString", b)
+}
+
+// WriteFixedString writes s in a field of size bytes, filling the rest with pad. It
+// fails with ErrTooLong, writing nothing, if s is longer than size. Trailing pad
+// bytes in s itself are lost when read back with ReadFixedString.
+func (w *baseWriter) WriteFixedString(s string, size int, pad byte) (n int, err error) {
+	if len(s) > size {
+		return 0, &OffsetError{Op: "WriteFixedString", Offset: w.off, Width: size, Err: fmt.Errorf("%w: %d bytes, max %d", ErrTooLong, len(s), size)}
+	}
+	b := make([]byte, size)
+	copy(b, s)
+	for i := len(s); i < size; i++ {
+		b[i] = pad
+	}
+	return w.write("WriteFixedString", b)
+}
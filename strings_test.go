@@ -0,0 +1,157 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadCString(t *testing.T) {
+	var tests = []struct {
+		name   string
+		data   []byte
+		maxLen int
+		want   string
+		rest   int // bytes left after the read
+	}{
+		{"Empty", []byte{0x00, 'x'}, 16, "", 1},
+		{"Simple", []byte{'a', 'b', 'c', 0x00}, 16, "abc", 0},
+		{"Trailing", []byte{'a', 'b', 0x00, 'c', 0x00}, 16, "ab", 2},
+		{"ExactMax", []byte{'a', 'b', 'c', 0x00}, 3, "abc", 0},
+		{"NoLimit", []byte{'a', 'b', 'c', 0x00}, -1, "abc", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			br := bytes.NewReader(tt.data)
+			r := NewBigEndianReader(br)
+			got, err := r.ReadCString(tt.maxLen)
+			if err != nil {
+				t.Fatalf("ReadCString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadCString() got = %q, want %q", got, tt.want)
+			}
+			if br.Len() != tt.rest {
+				t.Errorf("ReadCString() left %d bytes, want %d", br.Len(), tt.rest)
+			}
+		})
+	}
+
+	// Test error cases, without the io.ByteReader fast path as well
+	t.Run("ErrorCases", func(t *testing.T) {
+		for _, wrap := range []func(io.Reader) io.Reader{func(r io.Reader) io.Reader { return r }, iotest.OneByteReader} {
+			r := NewLittleEndianReader(wrap(bytes.NewReader([]byte("abcd\x00"))))
+			if _, err := r.ReadCString(3); !errors.Is(err, ErrTooLong) {
+				t.Errorf("ReadCString() error = %v, want %v", err, ErrTooLong)
+			}
+			r = NewLittleEndianReader(wrap(bytes.NewReader([]byte("abc"))))
+			_, err := r.ReadCString(16)
+			if !errors.Is(err, ErrUnterminated) || !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("ReadCString() error = %v, want %v and %v", err, ErrUnterminated, io.ErrUnexpectedEOF)
+			}
+			r = NewLittleEndianReader(wrap(bytes.NewReader(nil)))
+			if _, err := r.ReadCString(16); !errors.Is(err, io.EOF) || errors.Is(err, ErrUnterminated) {
+				t.Errorf("ReadCString() error = %v, want %v", err, io.EOF)
+			}
+		}
+	})
+}
+
+func TestReadFixedString(t *testing.T) {
+	var tests = []struct {
+		name string
+		data []byte
+		pad  byte
+		want string
+	}{
+		{"NulPadded", []byte{'a', 'b', 0, 0, 0, 0, 0, 0}, 0, "ab"},
+		{"NulGarbage", []byte{'a', 'b', 0, 'x', 'y', 0, 0, 0}, 0, "ab"},
+		{"SpacePadded", []byte{'a', ' ', 'b', ' ', ' ', ' ', ' ', ' '}, ' ', "a b"},
+		{"Full", []byte{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h'}, ' ', "abcdefgh"},
+		{"AllPad", []byte{' ', ' ', ' ', ' ', ' ', ' ', ' ', ' '}, ' ', ""},
+		// 0xFF is trimmed as a byte, not as the UTF-8 encoding of U+00FF
+		{"HighPad", []byte{'a', 0xc3, 0xbf, 0xff, 0xff, 0xff, 0xff, 0xff}, 0xff, "a\xc3\xbf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewBigEndianReader(bytes.NewReader(tt.data))
+			got, err := r.ReadFixedString(8, tt.pad)
+			if err != nil {
+				t.Fatalf("ReadFixedString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadFixedString() got = %q, want %q", got, tt.want)
+			}
+			if r.Offset() != 8 {
+				t.Errorf("Offset() got = %v, want %v", r.Offset(), 8)
+			}
+		})
+	}
+
+	r := NewBigEndianReader(bytes.NewReader([]byte("abc")))
+	if _, err := r.ReadFixedString(8, 0); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFixedString() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestWriteStrings(t *testing.T) {
+	// Test WriteCString
+	t.Run("WriteCString", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		w := NewLittleEndianWriter(buf)
+		n, err := w.WriteCString("abc")
+		if err != nil || n != 4 {
+			t.Fatalf("WriteCString() got = %v, %v, want 4", n, err)
+		}
+		if want := []byte("abc\x00"); !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("WriteCString() got = %v, want %v", buf.Bytes(), want)
+		}
+		if _, err := w.WriteCString("a\x00b"); !errors.Is(err, ErrNUL) {
+			t.Errorf("WriteCString() error = %v, want %v", err, ErrNUL)
+		}
+		if buf.Len() != 4 {
+			t.Errorf("WriteCString() wrote %d bytes of an invalid string", buf.Len()-4)
+		}
+	})
+
+	// Test WriteFixedString
+	t.Run("WriteFixedString", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			s    string
+			pad  byte
+			want []byte
+		}{
+			{"NulPadded", "ab", 0, []byte{'a', 'b', 0, 0, 0, 0}},
+			{"SpacePadded", "ab", ' ', []byte{'a', 'b', ' ', ' ', ' ', ' '}},
+			{"Full", "abcdef", ' ', []byte{'a', 'b', 'c', 'd', 'e', 'f'}},
+			{"HighPad", "abc", 0xff, []byte{'a', 'b', 'c', 0xff, 0xff, 0xff}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewBigEndianWriter(buf)
+				if _, err := w.WriteFixedString(tt.s, 6, tt.pad); err != nil {
+					t.Fatalf("WriteFixedString() error = %v", err)
+				}
+				if !bytes.Equal(buf.Bytes(), tt.want) {
+					t.Errorf("WriteFixedString() got = %v, want %v", buf.Bytes(), tt.want)
+				}
+				got, err := NewBigEndianReader(buf).ReadFixedString(6, tt.pad)
+				if err != nil || got != tt.s {
+					t.Errorf("ReadFixedString() got = %q, %v, want %q", got, err, tt.s)
+				}
+			})
+		}
+
+		buf := &bytes.Buffer{}
+		if _, err := NewBigEndianWriter(buf).WriteFixedString("abcdefg", 6, 0); !errors.Is(err, ErrTooLong) {
+			t.Errorf("WriteFixedString() error = %v, want %v", err, ErrTooLong)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("WriteFixedString() wrote %d bytes of a too long string", buf.Len())
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrInvalidUnicode is returned when UTF-16 or UTF-32 text contains an unpaired
+// surrogate or a value that is not a Unicode code point, and RejectInvalid is used.
+var ErrInvalidUnicode = errors.New("endianio: invalid UTF-16 or UTF-32 text")
+
+// InvalidPolicy selects what the UTF-16 and UTF-32 reads do with invalid text.
+type InvalidPolicy int
+
+const (
+	ReplaceInvalid InvalidPolicy = iota // replace invalid code units with U+FFFD
+	RejectInvalid                       // fail with ErrInvalidUnicode
+)
+
+// ReadUTF16 reads n UTF-16 code units in the byte order of the reader, and
+// returns them as a UTF-8 string. Surrogate pairs count as two code units. A byte
+// order mark is not treated specially; use DetectReader with MagicUTF16 for text
+// that starts with one.
+func (r *baseReader) ReadUTF16(n int, policy InvalidPolicy) (string, error) {
+	off := r.off
+	if err := r.checkLength("ReadUTF16", off, n, 2); err != nil {
+		return "", err
+	}
+	b := make([]byte, 2*n)
+	if err := r.readFull("ReadUTF16", b); err != nil {
+		return "", err
+	}
+	u := make([]uint16, n)
+	for i := range u {
+		u[i] = r.order.Uint16(b[2*i:])
+	}
+	return decodeUTF16("ReadUTF16", off, u, policy)
+}
+
+// ReadUTF16Z reads UTF-16 code units in the byte order of the reader up to a 0
+// code unit, and returns them without it as a UTF-8 string. If no 0 is found
+// within maxLen code units the read fails with ErrTooLong; a negative maxLen
+// means no limit. If the input ends first the error wraps ErrUnterminated.
+func (r *baseReader) ReadUTF16Z(maxLen int, policy InvalidPolicy) (string, error) {
+	off := r.off
+	var u []uint16
+	var b [2]byte
+	for {
+		if err := r.readZ("ReadUTF16Z", off, b[:], len(u), maxLen); err != nil {
+			return "", err
+		}
+		c := r.order.Uint16(b[:])
+		if c == 0 {
+			return decodeUTF16("ReadUTF16Z", off, u, policy)
+		}
+		if err := r.checkAlloc("ReadUTF16Z", off, len(u)+1, 2); err != nil {
+			return "", err
+		}
+		u = append(u, c)
+	}
+}
+
+// ReadUTF32 reads n UTF-32 code points in the byte order of the reader, and
+// returns them as a UTF-8 string.
+func (r *baseReader) ReadUTF32(n int, policy InvalidPolicy) (string, error) {
+	off := r.off
+	if err := r.checkLength("ReadUTF32", off, n, 4); err != nil {
+		return "", err
+	}
+	b := make([]byte, 4*n)
+	if err := r.readFull("ReadUTF32", b); err != nil {
+		return "", err
+	}
+	u := make([]uint32, n)
+	for i := range u {
+		u[i] = r.order.Uint32(b[4*i:])
+	}
+	return decodeUTF32("ReadUTF32", off, u, policy)
+}
+
+// ReadUTF32Z reads UTF-32 code points in the byte order of the reader up to a 0,
+// and returns them without it as a UTF-8 string. maxLen is handled as in
+// ReadUTF16Z, counted in code points.
+func (r *baseReader) ReadUTF32Z(maxLen int, policy InvalidPolicy) (string, error) {
+	off := r.off
+	var u []uint32
+	var b [4]byte
+	for {
+		if err := r.readZ("ReadUTF32Z", off, b[:], len(u), maxLen); err != nil {
+			return "", err
+		}
+		c := r.order.Uint32(b[:])
+		if c == 0 {
+			return decodeUTF32("ReadUTF32Z", off, u, policy)
+		}
+		if err := r.checkAlloc("ReadUTF32Z", off, len(u)+1, 4); err != nil {
+			return "", err
+		}
+		u = append(u, c)
+	}
+}
+
+// readZ reads the code unit after the n already read of a terminated string
+// starting at off into b.
+func (r *baseReader) readZ(op string, off int64, b []byte, n, maxLen int) error {
+	width := len(b)
+	if maxLen >= 0 && n > maxLen {
+		return &OffsetError{Op: op, Offset: off, Width: (n + 1) * width, N: n * width, Err: fmt.Errorf("%w: no terminator within %d code units", ErrTooLong, maxLen)}
+	}
+	k, err := r.fill(b)
+	if err != nil {
+		if n > 0 || k > 0 {
+			err = fmt.Errorf("%w: %w", ErrUnterminated, io.ErrUnexpectedEOF)
+		}
+		return &OffsetError{Op: op, Offset: off, Width: (n + 1) * width, N: n*width + k, Err: err}
+	}
+	return nil
+}
+
+// decodeUTF16 converts the UTF-16 text u, read from off, to UTF-8.
+func decodeUTF16(op string, off int64, u []uint16, policy InvalidPolicy) (string, error) {
+	b := make([]byte, 0, len(u))
+	for i := 0; i < len(u); i++ {
+		c := rune(u[i])
+		if utf16.IsSurrogate(c) {
+			if i+1 < len(u) {
+				if d := utf16.DecodeRune(c, rune(u[i+1])); d != utf8.RuneError {
+					b = utf8.AppendRune(b, d)
+					i++
+					continue
+				}
+			}
+			if policy == RejectInvalid {
+				return "", &OffsetError{Op: op, Offset: off + 2*int64(i), Width: 2, N: 2, Err: fmt.Errorf("%w: unpaired surrogate %#04x", ErrInvalidUnicode, c)}
+			}
+			c = utf8.RuneError
+		}
+		b = utf8.AppendRune(b, c)
+	}
+	return string(b), nil
+}
+
+// decodeUTF32 converts the UTF-32 text u, read from off, to UTF-8.
+func decodeUTF32(op string, off int64, u []uint32, policy InvalidPolicy) (string, error) {
+	b := make([]byte, 0, len(u))
+	for i, v := range u {
+		c := rune(v)
+		if v > utf8.MaxRune || utf16.IsSurrogate(c) {
+			if policy == RejectInvalid {
+				return "", &OffsetError{Op: op, Offset: off + 4*int64(i), Width: 4, N: 4, Err: fmt.Errorf("%w: %#x is not a code point", ErrInvalidUnicode, v)}
+			}
+			c = utf8.RuneError
+		}
+		b = utf8.AppendRune(b, c)
+	}
+	return string(b), nil
+}
+
+// WriteUTF16 writes s as UTF-16 in the byte order of the writer, preceded by a
+// byte order mark if bom is set. Invalid UTF-8 in s is written as U+FFFD.
+func (w *baseWriter) WriteUTF16(s string, bom bool) (n int, err error) {
+	return w.write("WriteUTF16", appendUTF16(w.order, nil, s, bom))
+}
+
+// WriteUTF16Z writes s as UTF-16 like WriteUTF16, followed by a 0 code unit.
+func (w *baseWriter) WriteUTF16Z(s string, bom bool) (n int, err error) {
+	return w.write("WriteUTF16Z", appendUint16(w.order, appendUTF16(w.order, nil, s, bom), 0))
+}
+
+// WriteUTF32 writes s as UTF-32 in the byte order of the writer, preceded by a
+// byte order mark if bom is set. Invalid UTF-8 in s is written as U+FFFD.
+func (w *baseWriter) WriteUTF32(s string, bom bool) (n int, err error) {
+	return w.write("WriteUTF32", appendUTF32(w.order, nil, s, bom))
+}
+
+// WriteUTF32Z writes s as UTF-32 like WriteUTF32, followed by a 0.
+func (w *baseWriter) WriteUTF32Z(s string, bom bool) (n int, err error) {
+	return w.write("WriteUTF32Z", appendUint32(w.order, appendUTF32(w.order, nil, s, bom), 0))
+}
+
+func appendUTF16(order binary.ByteOrder, b []byte, s string, bom bool) []byte {
+	if bom {
+		b = appendUint16(order, b, 0xfeff)
+	}
+	var u []uint16
+	for _, c := range s {
+		u = utf16.AppendRune(u[:0], c)
+		for _, v := range u {
+			b = appendUint16(order, b, v)
+		}
+	}
+	return b
+}
+
+func appendUTF32(order binary.ByteOrder, b []byte, s string, bom bool) []byte {
+	if bom {
+		b = appendUint32(order, b, 0xfeff)
+	}
+	for _, c := range s {
+		b = appendUint32(order, b, uint32(c))
+	}
+	return b
+}
+
+// appendUint16 appends v to b in the byte order order.
+func appendUint16(order binary.ByteOrder, b []byte, v uint16) []byte {
+	b = append(b, 0, 0)
+	order.PutUint16(b[len(b)-2:], v)
+	return b
+}
+
+// appendUint32 appends v to b in the byte order order.
+func appendUint32(order binary.ByteOrder, b []byte, v uint32) []byte {
+	b = append(b, 0, 0, 0, 0)
+	order.PutUint32(b[len(b)-4:], v)
+	return b
+}
@@ -0,0 +1,157 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadUTF16(t *testing.T) {
+	var tests = []struct {
+		name string
+		be   []byte
+		want string
+	}{
+		{"ASCII", []byte{0x00, 'h', 0x00, 'i'}, "hi"},
+		{"BMP", []byte{0x00, 0xe9, 0x20, 0xac}, "é€"},
+		{"SurrogatePair", []byte{0xd8, 0x3d, 0xde, 0x00, 0x00, '!'}, "😀!"},
+		{"UnpairedHigh", []byte{0xd8, 0x3d, 0x00, 'a'}, "�a"},
+		{"UnpairedLow", []byte{0xde, 0x00, 0x00, 'a'}, "�a"},
+		{"HighAtEnd", []byte{0x00, 'a', 0xd8, 0x3d}, "a�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := len(tt.be) / 2
+			le := make([]byte, len(tt.be))
+			for i := 0; i < len(le); i += 2 {
+				le[i], le[i+1] = tt.be[i+1], tt.be[i]
+			}
+
+			got, err := NewBigEndianReader(bytes.NewReader(tt.be)).ReadUTF16(n, ReplaceInvalid)
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUTF16() big-endian got = %q, %v, want %q", got, err, tt.want)
+			}
+			got, err = NewLittleEndianReader(bytes.NewReader(le)).ReadUTF16(n, ReplaceInvalid)
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUTF16() little-endian got = %q, %v, want %q", got, err, tt.want)
+			}
+			got, err = NewLittleEndianReader(bytes.NewReader(append(le, 0, 0))).ReadUTF16Z(-1, ReplaceInvalid)
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUTF16Z() got = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	// Test error cases
+	t.Run("ErrorCases", func(t *testing.T) {
+		r := NewBigEndianReader(bytes.NewReader([]byte{0x00, 'a', 0xde, 0x00}))
+		_, err := r.ReadUTF16(2, RejectInvalid)
+		var oe *OffsetError
+		if !errors.Is(err, ErrInvalidUnicode) || !errors.As(err, &oe) || oe.Offset != 2 {
+			t.Errorf("ReadUTF16() error = %v, want %v at offset 2", err, ErrInvalidUnicode)
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x00, 'a', 0x00}))
+		if _, err := r.ReadUTF16(2, ReplaceInvalid); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadUTF16() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x00, 'a', 0x00, 'b', 0x00, 0x00}))
+		if _, err := r.ReadUTF16Z(1, ReplaceInvalid); !errors.Is(err, ErrTooLong) {
+			t.Errorf("ReadUTF16Z() error = %v, want %v", err, ErrTooLong)
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x00, 'a', 0x00, 'b', 0x00, 0x00}))
+		if got, err := r.ReadUTF16Z(2, ReplaceInvalid); err != nil || got != "ab" {
+			t.Errorf("ReadUTF16Z() got = %q, %v, want %q", got, err, "ab")
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x00, 'a', 0x00}))
+		if _, err := r.ReadUTF16Z(-1, ReplaceInvalid); !errors.Is(err, ErrUnterminated) || !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadUTF16Z() error = %v, want %v", err, ErrUnterminated)
+		}
+		r = NewBigEndianReader(bytes.NewReader(nil))
+		if _, err := r.ReadUTF16Z(-1, ReplaceInvalid); !errors.Is(err, io.EOF) {
+			t.Errorf("ReadUTF16Z() error = %v, want %v", err, io.EOF)
+		}
+	})
+}
+
+func TestReadUTF32(t *testing.T) {
+	var tests = []struct {
+		name string
+		le   []byte
+		want string
+	}{
+		{"ASCII", []byte{'h', 0, 0, 0, 'i', 0, 0, 0}, "hi"},
+		{"Astral", []byte{0x00, 0xf6, 0x01, 0x00}, "😀"},
+		{"Surrogate", []byte{0x00, 0xd8, 0x00, 0x00, 'a', 0, 0, 0}, "�a"},
+		{"TooLarge", []byte{0x00, 0x00, 0x11, 0x00}, "�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := len(tt.le) / 4
+			got, err := NewLittleEndianReader(bytes.NewReader(tt.le)).ReadUTF32(n, ReplaceInvalid)
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUTF32() got = %q, %v, want %q", got, err, tt.want)
+			}
+			got, err = NewLittleEndianReader(bytes.NewReader(append(tt.le, 0, 0, 0, 0))).ReadUTF32Z(-1, ReplaceInvalid)
+			if err != nil || got != tt.want {
+				t.Errorf("ReadUTF32Z() got = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	r := NewBigEndianReader(bytes.NewReader([]byte{0x00, 0x11, 0x00, 0x00}))
+	if _, err := r.ReadUTF32(1, RejectInvalid); !errors.Is(err, ErrInvalidUnicode) {
+		t.Errorf("ReadUTF32() error = %v, want %v", err, ErrInvalidUnicode)
+	}
+}
+
+func TestWriteUnicode(t *testing.T) {
+	var tests = []struct {
+		name  string
+		write func(w EndianWriter) (int, error)
+		be    []byte
+	}{
+		{"UTF16", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF16("a😀", false) }, []byte{0x00, 'a', 0xd8, 0x3d, 0xde, 0x00}},
+		{"UTF16_BOM", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF16("a", true) }, []byte{0xfe, 0xff, 0x00, 'a'}},
+		{"UTF16Z", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF16Z("a", false) }, []byte{0x00, 'a', 0x00, 0x00}},
+		{"UTF16_InvalidUTF8", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF16("\xff", false) }, []byte{0xff, 0xfd}},
+		{"UTF32", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF32("😀", false) }, []byte{0x00, 0x01, 0xf6, 0x00}},
+		{"UTF32_BOM", func(w EndianWriter) (int, error) { return w.(unicodeWriter).WriteUTF32Z("a", true) }, []byte{0, 0, 0xfe, 0xff, 0, 0, 0, 'a', 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			n, err := tt.write(NewBigEndianWriter(buf))
+			if err != nil || n != len(tt.be) {
+				t.Fatalf("write got = %v, %v, want %v", n, err, len(tt.be))
+			}
+			if !bytes.Equal(buf.Bytes(), tt.be) {
+				t.Errorf("big-endian got = %v, want %v", buf.Bytes(), tt.be)
+			}
+
+			// The little-endian form has every code unit reversed
+			buf.Reset()
+			tt.write(NewLittleEndianWriter(buf))
+			unit := 2
+			if tt.name[:5] == "UTF32" {
+				unit = 4
+			}
+			got := buf.Bytes()
+			for i := 0; i < len(got); i += unit {
+				for j := 0; j < unit; j++ {
+					if got[i+j] != tt.be[i+unit-1-j] {
+						t.Fatalf("little-endian got = %v, want %v reversed per code unit", got, tt.be)
+					}
+				}
+			}
+		})
+	}
+}
+
+// unicodeWriter is implemented by BigEndianWriter and LittleEndianWriter.
+type unicodeWriter interface {
+	WriteUTF16(s string, bom bool) (int, error)
+	WriteUTF16Z(s string, bom bool) (int, error)
+	WriteUTF32(s string, bom bool) (int, error)
+	WriteUTF32Z(s string, bom bool) (int, error)
+}
@@ -0,0 +1,131 @@
+package endianio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// MaxVarintLen64 is the maximum length in bytes of a 64-bit varint or LEB128 value.
+const MaxVarintLen64 = binary.MaxVarintLen64
+
+// ErrOverflow is returned when a variable-length integer does not fit in 64 bits,
+// i.e. it has too many continuation bytes.
+var ErrOverflow = errors.New("endianio: varint overflows a 64-bit integer")
+
+// ReadUvarint reads an unsigned protobuf style varint.
+func (r *baseReader) ReadUvarint() (uint64, error) {
+	return r.readUvarint("ReadUvarint")
+}
+
+// ReadVarint reads a zigzag encoded signed protobuf style varint.
+func (r *baseReader) ReadVarint() (int64, error) {
+	ux, err := r.readUvarint("ReadVarint")
+	if err != nil {
+		return 0, err
+	}
+	return int64(ux>>1) ^ -int64(ux&1), nil
+}
+
+// ReadULEB128 reads an unsigned LEB128 value.
+func (r *baseReader) ReadULEB128() (uint64, error) {
+	return r.readUvarint("ReadULEB128")
+}
+
+// ReadSLEB128 reads a signed LEB128 value.
+func (r *baseReader) ReadSLEB128() (int64, error) {
+	off := r.off
+	var v int64
+	var shift uint
+	for i := 0; i < MaxVarintLen64; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, varintError("ReadSLEB128", off, i, err)
+		}
+		if i == MaxVarintLen64-1 && b != 0x00 && b != 0x7f {
+			return 0, varintError("ReadSLEB128", off, i+1, ErrOverflow)
+		}
+		v |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				v |= -1 << shift
+			}
+			return v, nil
+		}
+	}
+	return 0, varintError("ReadSLEB128", off, MaxVarintLen64, ErrOverflow)
+}
+
+// readUvarint reads an unsigned base 128 value, which is the encoding shared by
+// protobuf varints and unsigned LEB128.
+func (r *baseReader) readUvarint(op string) (uint64, error) {
+	off := r.off
+	var v uint64
+	var shift uint
+	for i := 0; i < MaxVarintLen64; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, varintError(op, off, i, err)
+		}
+		if b < 0x80 {
+			if i == MaxVarintLen64-1 && b > 1 {
+				return 0, varintError(op, off, i+1, ErrOverflow)
+			}
+			return v | uint64(b)<<shift, nil
+		}
+		v |= uint64(b&0x7f) << shift
+		shift += 7
+	}
+	return 0, varintError(op, off, MaxVarintLen64, ErrOverflow)
+}
+
+// varintError returns an *OffsetError for a variable-length integer starting at
+// off that failed after n bytes. Running out of input after the first byte is
+// reported as io.ErrUnexpectedEOF.
+func varintError(op string, off int64, n int, err error) error {
+	width := n
+	if err != ErrOverflow {
+		width = n + 1
+		if n > 0 && err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+	}
+	return &OffsetError{Op: op, Offset: off, Width: width, N: n, Err: err}
+}
+
+// WriteUvarint writes an unsigned protobuf style varint.
+func (w *baseWriter) WriteUvarint(v uint64) (n int, err error) {
+	var b [MaxVarintLen64]byte
+	return w.write("WriteUvarint", binary.AppendUvarint(b[:0], v))
+}
+
+// WriteVarint writes a zigzag encoded signed protobuf style varint.
+func (w *baseWriter) WriteVarint(v int64) (n int, err error) {
+	var b [MaxVarintLen64]byte
+	return w.write("WriteVarint", binary.AppendVarint(b[:0], v))
+}
+
+// WriteULEB128 writes an unsigned LEB128 value.
+func (w *baseWriter) WriteULEB128(v uint64) (n int, err error) {
+	var b [MaxVarintLen64]byte
+	return w.write("WriteULEB128", binary.AppendUvarint(b[:0], v))
+}
+
+// WriteSLEB128 writes a signed LEB128 value.
+func (w *baseWriter) WriteSLEB128(v int64) (n int, err error) {
+	var b [MaxVarintLen64]byte
+	i := 0
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			b[i] = c
+			i++
+			break
+		}
+		b[i] = c | 0x80
+		i++
+	}
+	return w.write("WriteSLEB128", b[:i])
+}
@@ -0,0 +1,237 @@
+package endianio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestReadVarints(t *testing.T) {
+	// Test ReadUvarint
+	t.Run("ReadUvarint", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want uint64
+		}{
+			{"Uvarint_1", []byte{0x00}, 0},
+			{"Uvarint_2", []byte{0x01}, 1},
+			{"Uvarint_3", []byte{0x7f}, 127},
+			{"Uvarint_4", []byte{0x96, 0x01}, 150},
+			{"Uvarint_5", []byte{0xac, 0x02}, 300},
+			{"Uvarint_6", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, math.MaxUint32},
+			{"Uvarint_7", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, math.MaxUint64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadUvarint()
+				if err != nil {
+					t.Errorf("ReadUvarint() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadUvarint() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadVarint
+	t.Run("ReadVarint", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int64
+		}{
+			{"Varint_1", []byte{0x00}, 0},
+			{"Varint_2", []byte{0x01}, -1},
+			{"Varint_3", []byte{0x02}, 1},
+			{"Varint_4", []byte{0x03}, -2},
+			{"Varint_5", []byte{0xfe, 0xff, 0xff, 0xff, 0x0f}, math.MaxInt32},
+			{"Varint_6", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, math.MinInt32},
+			{"Varint_7", []byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, math.MaxInt64},
+			{"Varint_8", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, math.MinInt64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadVarint()
+				if err != nil {
+					t.Errorf("ReadVarint() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadVarint() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadULEB128, values from the DWARF specification
+	t.Run("ReadULEB128", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want uint64
+		}{
+			{"ULEB128_1", []byte{0x02}, 2},
+			{"ULEB128_2", []byte{0x7f}, 127},
+			{"ULEB128_3", []byte{0x80, 0x01}, 128},
+			{"ULEB128_4", []byte{0x81, 0x01}, 129},
+			{"ULEB128_5", []byte{0x82, 0x01}, 130},
+			{"ULEB128_6", []byte{0xb9, 0x64}, 12857},
+			{"ULEB128_7", []byte{0xe5, 0x8e, 0x26}, 624485},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewBigEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadULEB128()
+				if err != nil {
+					t.Errorf("ReadULEB128() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadULEB128() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test ReadSLEB128, values from the DWARF specification
+	t.Run("ReadSLEB128", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			data []byte
+			want int64
+		}{
+			{"SLEB128_1", []byte{0x02}, 2},
+			{"SLEB128_2", []byte{0x7e}, -2},
+			{"SLEB128_3", []byte{0xff, 0x00}, 127},
+			{"SLEB128_4", []byte{0x81, 0x7f}, -127},
+			{"SLEB128_5", []byte{0x80, 0x01}, 128},
+			{"SLEB128_6", []byte{0x80, 0x7f}, -128},
+			{"SLEB128_7", []byte{0x81, 0x01}, 129},
+			{"SLEB128_8", []byte{0xff, 0x7e}, -129},
+			{"SLEB128_9", []byte{0xc0, 0xbb, 0x78}, -123456},
+			{"SLEB128_10", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}, math.MaxInt64},
+			{"SLEB128_11", []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f}, math.MinInt64},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r := NewLittleEndianReader(bytes.NewReader(tt.data))
+				got, err := r.ReadSLEB128()
+				if err != nil {
+					t.Errorf("ReadSLEB128() error = %v", err)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("ReadSLEB128() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test overflow and truncated input
+	t.Run("ErrorCases", func(t *testing.T) {
+		tooLong := []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}
+		tooBig := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}
+
+		r := NewBigEndianReader(bytes.NewReader(tooLong))
+		if _, err := r.ReadUvarint(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadUvarint() error = %v, want %v", err, ErrOverflow)
+		}
+		r = NewBigEndianReader(bytes.NewReader(tooBig))
+		if _, err := r.ReadULEB128(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadULEB128() error = %v, want %v", err, ErrOverflow)
+		}
+		r = NewBigEndianReader(bytes.NewReader(tooLong))
+		if _, err := r.ReadSLEB128(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadSLEB128() error = %v, want %v", err, ErrOverflow)
+		}
+		r = NewBigEndianReader(bytes.NewReader(tooBig))
+		if _, err := r.ReadSLEB128(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ReadSLEB128() error = %v, want %v", err, ErrOverflow)
+		}
+
+		r = NewBigEndianReader(bytes.NewReader(nil))
+		if _, err := r.ReadUvarint(); !errors.Is(err, io.EOF) {
+			t.Errorf("ReadUvarint() error = %v, want %v", err, io.EOF)
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x80, 0x80}))
+		if _, err := r.ReadUvarint(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadUvarint() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		r = NewBigEndianReader(bytes.NewReader([]byte{0x80}))
+		if _, err := r.ReadSLEB128(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadSLEB128() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestWriteVarints(t *testing.T) {
+	// Test WriteSLEB128
+	t.Run("WriteSLEB128", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int64
+			want  []byte
+		}{
+			{"SLEB128_1", 2, []byte{0x02}},
+			{"SLEB128_2", -2, []byte{0x7e}},
+			{"SLEB128_3", 127, []byte{0xff, 0x00}},
+			{"SLEB128_4", -127, []byte{0x81, 0x7f}},
+			{"SLEB128_5", 128, []byte{0x80, 0x01}},
+			{"SLEB128_6", -128, []byte{0x80, 0x7f}},
+			{"SLEB128_7", -123456, []byte{0xc0, 0xbb, 0x78}},
+			{"SLEB128_8", math.MinInt64, []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewLittleEndianWriter(buf)
+				_, err := w.WriteSLEB128(tt.value)
+				if err != nil {
+					t.Errorf("WriteSLEB128() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteSLEB128() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test round trips through the reader
+	t.Run("RoundTrip", func(t *testing.T) {
+		values := []int64{0, 1, -1, 63, -64, 64, -65, 150, -300, math.MaxInt32, math.MinInt32, math.MaxInt64, math.MinInt64}
+		for _, v := range values {
+			buf := &bytes.Buffer{}
+			w := NewBigEndianWriter(buf)
+			w.WriteUvarint(uint64(v))
+			w.WriteVarint(v)
+			w.WriteULEB128(uint64(v))
+			w.WriteSLEB128(v)
+
+			r := NewBigEndianReader(buf)
+			if got, err := r.ReadUvarint(); err != nil || got != uint64(v) {
+				t.Errorf("ReadUvarint() got = %v, %v, want %v", got, err, uint64(v))
+			}
+			if got, err := r.ReadVarint(); err != nil || got != v {
+				t.Errorf("ReadVarint() got = %v, %v, want %v", got, err, v)
+			}
+			if got, err := r.ReadULEB128(); err != nil || got != uint64(v) {
+				t.Errorf("ReadULEB128() got = %v, %v, want %v", got, err, uint64(v))
+			}
+			if got, err := r.ReadSLEB128(); err != nil || got != v {
+				t.Errorf("ReadSLEB128() got = %v, %v, want %v", got, err, v)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("%d bytes left after reading %v", buf.Len(), v)
+			}
+		}
+	})
+}
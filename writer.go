@@ -3,6 +3,7 @@ package endianio
 
 import (
 	"encoding/binary"
+	"hash"
 	"io"
 	"math"
 )
@@ -17,22 +18,100 @@ type EndianWriter interface {
 	WriteUint32(v uint32) (n int, err error)
 	// WriteUint64 writes a 64-bit unsigned integer
 	WriteUint64(v uint64) (n int, err error)
+	// WriteInt8 writes an int8
+	WriteInt8(v int8) (n int, err error)
+	// WriteInt16 writes a 16-bit signed integer
+	WriteInt16(v int16) (n int, err error)
+	// WriteInt32 writes a 32-bit signed integer
+	WriteInt32(v int32) (n int, err error)
+	// WriteInt64 writes a 64-bit signed integer
+	WriteInt64(v int64) (n int, err error)
 	// WriteFloat32 writes a 32-bit float
 	WriteFloat32(v float32) (n int, err error)
 	// WriteFloat64 writes a 64-bit float
 	WriteFloat64(v float64) (n int, err error)
+	// Order returns the byte order of the multi-byte writes
+	Order() binary.ByteOrder
 }
 
 // baseWriter provides common functionality for both big-endian and little-endian writers.
 type baseWriter struct {
 	io.Writer
+	order binary.ByteOrder
+	off   int64
+	hash  hash.Hash // set by SetHash
+}
+
+// NewWriter creates a writer for the byte order given at run time, which is a
+// *BigEndianWriter or a *LittleEndianWriter for those orders, and a
+// *ByteOrderWriter for any other, such as OrderCDAB.
+func NewWriter(w io.Writer, order binary.ByteOrder) EndianWriter {
+	switch big, ok := isBigEndian(order); {
+	case !ok:
+		return NewByteOrderWriter(w, order)
+	case big:
+		return NewBigEndianWriter(w)
+	}
+	return NewLittleEndianWriter(w)
+}
+
+// NewNativeEndianWriter creates a writer using the byte order of the host.
+func NewNativeEndianWriter(w io.Writer) EndianWriter {
+	return NewWriter(w, binary.NativeEndian)
+}
+
+// Write implements io.Writer, counting the bytes written.
+func (w *baseWriter) Write(p []byte) (n int, err error) {
+	n, err = w.Writer.Write(p)
+	w.written(p[:n])
+	return n, err
+}
+
+// Order returns the byte order of the writer.
+func (w *baseWriter) Order() binary.ByteOrder {
+	return w.order
+}
+
+// Offset returns the number of bytes written so far.
+func (w *baseWriter) Offset() int64 {
+	return w.off
+}
+
+// write writes b on behalf of the method op, wrapping a failure in an *OffsetError.
+func (w *baseWriter) write(op string, b []byte) (n int, err error) {
+	off := w.off
+	n, err = w.Writer.Write(b)
+	w.written(b[:n])
+	if err == nil && n < len(b) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		return n, &OffsetError{Op: op, Offset: off, Width: len(b), N: n, Err: err}
+	}
+	return n, nil
+}
+
+// written counts the bytes b written to the wrapped writer, and adds them to the
+// attached hash.
+func (w *baseWriter) written(b []byte) {
+	w.off += int64(len(b))
+	if w.hash != nil {
+		w.hash.Write(b)
+	}
 }
 
 // WriteUint8 writes a uint8 (byte)
 func (w *baseWriter) WriteUint8(v uint8) (n int, err error) {
 	var b [1]byte
 	b[0] = v
-	return w.Write(b[:])
+	return w.write("WriteUint8", b[:])
+}
+
+// WriteInt8 writes an int8
+func (w *baseWriter) WriteInt8(v int8) (n int, err error) {
+	var b [1]byte
+	b[0] = uint8(v)
+	return w.write("WriteInt8", b[:])
 }
 
 // BigEndianWriter writes binary data in big-endian format.
@@ -42,42 +121,63 @@ type BigEndianWriter struct {
 
 // NewBigEndianWriter creates a new BigEndianWriter writing to the provided io.Writer.
 func NewBigEndianWriter(w io.Writer) *BigEndianWriter {
-	return &BigEndianWriter{baseWriter{w}}
+	return &BigEndianWriter{baseWriter{Writer: w, order: binary.BigEndian}}
 }
 
 // WriteUint16 writes a 16-bit unsigned integer in big-endian format.
 func (w *BigEndianWriter) WriteUint16(v uint16) (n int, err error) {
 	var b [2]byte
 	binary.BigEndian.PutUint16(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint16", b[:])
 }
 
 // WriteUint32 writes a 32-bit unsigned integer in big-endian format.
 func (w *BigEndianWriter) WriteUint32(v uint32) (n int, err error) {
 	var b [4]byte
 	binary.BigEndian.PutUint32(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint32", b[:])
 }
 
 // WriteUint64 writes a 64-bit unsigned integer in big-endian format.
 func (w *BigEndianWriter) WriteUint64(v uint64) (n int, err error) {
 	var b [8]byte
 	binary.BigEndian.PutUint64(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint64", b[:])
+}
+
+// WriteInt16 writes a 16-bit signed integer in big-endian format.
+func (w *BigEndianWriter) WriteInt16(v int16) (n int, err error) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(v))
+	return w.write("WriteInt16", b[:])
+}
+
+// WriteInt32 writes a 32-bit signed integer in big-endian format.
+func (w *BigEndianWriter) WriteInt32(v int32) (n int, err error) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	return w.write("WriteInt32", b[:])
+}
+
+// WriteInt64 writes a 64-bit signed integer in big-endian format.
+func (w *BigEndianWriter) WriteInt64(v int64) (n int, err error) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	return w.write("WriteInt64", b[:])
 }
 
 // WriteFloat32 writes a 32-bit float encoded as a 32-bit unsigned integer in big-endian format.
 func (w *BigEndianWriter) WriteFloat32(v float32) (n int, err error) {
 	var b [4]byte
 	binary.BigEndian.PutUint32(b[:], math.Float32bits(v))
-	return w.Write(b[:])
+	return w.write("WriteFloat32", b[:])
 }
 
 // WriteFloat64 writes a 64-bit float encoded as a 64-bit unsigned integer in big-endian format.
 func (w *BigEndianWriter) WriteFloat64(v float64) (n int, err error) {
 	var b [8]byte
 	binary.BigEndian.PutUint64(b[:], math.Float64bits(v))
-	return w.Write(b[:])
+	return w.write("WriteFloat64", b[:])
 }
 
 // LittleEndianWriter writes binary data in little-endian format.
@@ -87,40 +187,129 @@ type LittleEndianWriter struct {
 
 // NewLittleEndianWriter creates a new LittleEndianWriter writing to the provided io.Writer.
 func NewLittleEndianWriter(w io.Writer) *LittleEndianWriter {
-	return &LittleEndianWriter{baseWriter{w}}
+	return &LittleEndianWriter{baseWriter{Writer: w, order: binary.LittleEndian}}
 }
 
 // WriteUint16 writes a 16-bit unsigned integer in little-endian format.
 func (w *LittleEndianWriter) WriteUint16(v uint16) (n int, err error) {
 	var b [2]byte
 	binary.LittleEndian.PutUint16(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint16", b[:])
 }
 
 // WriteUint32 writes a 32-bit unsigned integer in little-endian format.
 func (w *LittleEndianWriter) WriteUint32(v uint32) (n int, err error) {
 	var b [4]byte
 	binary.LittleEndian.PutUint32(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint32", b[:])
 }
 
 // WriteUint64 writes a 64-bit unsigned integer in little-endian format.
 func (w *LittleEndianWriter) WriteUint64(v uint64) (n int, err error) {
 	var b [8]byte
 	binary.LittleEndian.PutUint64(b[:], v)
-	return w.Write(b[:])
+	return w.write("WriteUint64", b[:])
+}
+
+// WriteInt16 writes a 16-bit signed integer in little-endian format.
+func (w *LittleEndianWriter) WriteInt16(v int16) (n int, err error) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], uint16(v))
+	return w.write("WriteInt16", b[:])
+}
+
+// WriteInt32 writes a 32-bit signed integer in little-endian format.
+func (w *LittleEndianWriter) WriteInt32(v int32) (n int, err error) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(v))
+	return w.write("WriteInt32", b[:])
+}
+
+// WriteInt64 writes a 64-bit signed integer in little-endian format.
+func (w *LittleEndianWriter) WriteInt64(v int64) (n int, err error) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	return w.write("WriteInt64", b[:])
 }
 
 // WriteFloat32 writes a 32-bit float encoded as a 32-bit unsigned integer in little-endian format.
 func (w *LittleEndianWriter) WriteFloat32(v float32) (n int, err error) {
 	var b [4]byte
 	binary.LittleEndian.PutUint32(b[:], math.Float32bits(v))
-	return w.Write(b[:])
+	return w.write("WriteFloat32", b[:])
 }
 
 // WriteFloat64 writes a 64-bit float encoded as a 64-bit unsigned integer in little-endian format.
 func (w *LittleEndianWriter) WriteFloat64(v float64) (n int, err error) {
 	var b [8]byte
 	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
-	return w.Write(b[:])
+	return w.write("WriteFloat64", b[:])
+}
+
+// ByteOrderWriter writes binary data in any byte order, including the
+// middle-endian OrderCDAB and OrderBADC.
+type ByteOrderWriter struct {
+	baseWriter
+}
+
+// NewByteOrderWriter creates a new ByteOrderWriter writing to the provided
+// io.Writer in the byte order order.
+func NewByteOrderWriter(w io.Writer, order binary.ByteOrder) *ByteOrderWriter {
+	return &ByteOrderWriter{baseWriter{Writer: w, order: order}}
+}
+
+// WriteUint16 writes a 16-bit unsigned integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteUint16(v uint16) (n int, err error) {
+	var b [2]byte
+	w.order.PutUint16(b[:], v)
+	return w.write("WriteUint16", b[:])
+}
+
+// WriteUint32 writes a 32-bit unsigned integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteUint32(v uint32) (n int, err error) {
+	var b [4]byte
+	w.order.PutUint32(b[:], v)
+	return w.write("WriteUint32", b[:])
+}
+
+// WriteUint64 writes a 64-bit unsigned integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteUint64(v uint64) (n int, err error) {
+	var b [8]byte
+	w.order.PutUint64(b[:], v)
+	return w.write("WriteUint64", b[:])
+}
+
+// WriteInt16 writes a 16-bit signed integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteInt16(v int16) (n int, err error) {
+	var b [2]byte
+	w.order.PutUint16(b[:], uint16(v))
+	return w.write("WriteInt16", b[:])
+}
+
+// WriteInt32 writes a 32-bit signed integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteInt32(v int32) (n int, err error) {
+	var b [4]byte
+	w.order.PutUint32(b[:], uint32(v))
+	return w.write("WriteInt32", b[:])
+}
+
+// WriteInt64 writes a 64-bit signed integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteInt64(v int64) (n int, err error) {
+	var b [8]byte
+	w.order.PutUint64(b[:], uint64(v))
+	return w.write("WriteInt64", b[:])
+}
+
+// WriteFloat32 writes a 32-bit float encoded as a 32-bit unsigned integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteFloat32(v float32) (n int, err error) {
+	var b [4]byte
+	w.order.PutUint32(b[:], math.Float32bits(v))
+	return w.write("WriteFloat32", b[:])
+}
+
+// WriteFloat64 writes a 64-bit float encoded as a 64-bit unsigned integer in the byte order of the writer.
+func (w *ByteOrderWriter) WriteFloat64(v float64) (n int, err error) {
+	var b [8]byte
+	w.order.PutUint64(b[:], math.Float64bits(v))
+	return w.write("WriteFloat64", b[:])
 }
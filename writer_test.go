@@ -2,7 +2,9 @@ package endianio
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -206,6 +208,129 @@ func TestBigEndianWriter(t *testing.T) {
 		}
 	})
 
+	// Test WriteInt8
+	t.Run("WriteInt8", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int8
+			want  []byte
+		}{
+			{"Int8_1", 0, []byte{0x00}},
+			{"Int8_2", math.MaxInt8, []byte{0x7f}},
+			{"Int8_3", math.MinInt8, []byte{0x80}},
+			{"Int8_4", -1, []byte{0xff}},
+			{"Int8_5", -91, []byte{0xa5}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewBigEndianWriter(buf)
+				_, err := w.WriteInt8(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt8() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt8() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt16
+	t.Run("WriteInt16", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int16
+			want  []byte
+		}{
+			{"Int16_1", 0, []byte{0x00, 0x00}},
+			{"Int16_2", -1, []byte{0xff, 0xff}},
+			{"Int16_3", 0x1234, []byte{0x12, 0x34}},
+			{"Int16_4", -0x1234, []byte{0xed, 0xcc}},
+			{"Int16_5", math.MaxInt16, []byte{0x7f, 0xff}},
+			{"Int16_6", math.MinInt16, []byte{0x80, 0x00}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewBigEndianWriter(buf)
+				_, err := w.WriteInt16(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt16() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt16() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt32
+	t.Run("WriteInt32", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int32
+			want  []byte
+		}{
+			{"Int32_1", 0, []byte{0x00, 0x00, 0x00, 0x00}},
+			{"Int32_2", -1, []byte{0xff, 0xff, 0xff, 0xff}},
+			{"Int32_3", 0x12345678, []byte{0x12, 0x34, 0x56, 0x78}},
+			{"Int32_4", -0x12345678, []byte{0xed, 0xcb, 0xa9, 0x88}},
+			{"Int32_5", math.MaxInt32, []byte{0x7f, 0xff, 0xff, 0xff}},
+			{"Int32_6", math.MinInt32, []byte{0x80, 0x00, 0x00, 0x00}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewBigEndianWriter(buf)
+				_, err := w.WriteInt32(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt32() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt32() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt64
+	t.Run("WriteInt64", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int64
+			want  []byte
+		}{
+			{"Int64_1", 0, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+			{"Int64_2", -1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			{"Int64_3", 0x123456789ABCDEF0, []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}},
+			{"Int64_4", -0x123456789ABCDEF0, []byte{0xed, 0xcb, 0xa9, 0x87, 0x65, 0x43, 0x21, 0x10}},
+			{"Int64_5", math.MaxInt64, []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			{"Int64_6", math.MinInt64, []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewBigEndianWriter(buf)
+				_, err := w.WriteInt64(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt64() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt64() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
 	// Test error cases
 	t.Run("ErrorCases", func(t *testing.T) {
 		// Test writing to a failing writer
@@ -232,10 +357,55 @@ func TestBigEndianWriter(t *testing.T) {
 			if err == nil {
 				t.Errorf("WriteUint64() expected error for failing writer")
 			}
+
+			_, err = w.WriteInt32(-0x12345678)
+			if err == nil {
+				t.Errorf("WriteInt32() expected error for failing writer")
+			}
 		})
 	})
 }
 
+func TestNewWriter(t *testing.T) {
+	var tests = []struct {
+		name  string
+		order binary.ByteOrder
+		want  []byte
+	}{
+		{"BigEndian", binary.BigEndian, []byte{0x12, 0x34}},
+		{"LittleEndian", binary.LittleEndian, []byte{0x34, 0x12}},
+		{"NativeEndian", binary.NativeEndian, binary.NativeEndian.AppendUint16(nil, 0x1234)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := NewWriter(buf, tt.order)
+			if _, err := w.WriteUint16(0x1234); err != nil {
+				t.Fatalf("WriteUint16() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("WriteUint16() got = %v, want %v", buf.Bytes(), tt.want)
+			}
+			if got := w.Order().Uint16(tt.want); got != 0x1234 {
+				t.Errorf("Order() got = %v, want %v", w.Order(), tt.order)
+			}
+		})
+	}
+
+	if _, ok := NewWriter(nil, binary.BigEndian).(*BigEndianWriter); !ok {
+		t.Errorf("NewWriter(BigEndian) is not a *BigEndianWriter")
+	}
+	if _, ok := NewWriter(nil, binary.LittleEndian).(*LittleEndianWriter); !ok {
+		t.Errorf("NewWriter(LittleEndian) is not a *LittleEndianWriter")
+	}
+	if w := NewNativeEndianWriter(nil); w.Order().Uint16([]byte{1, 0}) != binary.NativeEndian.Uint16([]byte{1, 0}) {
+		t.Errorf("NewNativeEndianWriter() Order() got = %v, want %v", w.Order(), binary.NativeEndian)
+	}
+	if _, ok := NewWriter(nil, OrderBADC).(*ByteOrderWriter); !ok {
+		t.Errorf("NewWriter(OrderBADC) is not a *ByteOrderWriter")
+	}
+}
+
 func BenchmarkBigEndianWriter_WriteUint16(b *testing.B) {
 	buf := &bytes.Buffer{}
 	w := NewBigEndianWriter(buf)
@@ -467,6 +637,129 @@ func TestLittleEndianWriter(t *testing.T) {
 		}
 	})
 
+	// Test WriteInt8
+	t.Run("WriteInt8", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int8
+			want  []byte
+		}{
+			{"Int8_1", 0, []byte{0x00}},
+			{"Int8_2", math.MaxInt8, []byte{0x7f}},
+			{"Int8_3", math.MinInt8, []byte{0x80}},
+			{"Int8_4", -1, []byte{0xff}},
+			{"Int8_5", -91, []byte{0xa5}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewLittleEndianWriter(buf)
+				_, err := w.WriteInt8(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt8() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt8() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt16
+	t.Run("WriteInt16", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int16
+			want  []byte
+		}{
+			{"Int16_1", 0, []byte{0x00, 0x00}},
+			{"Int16_2", -1, []byte{0xff, 0xff}},
+			{"Int16_3", 0x1234, []byte{0x34, 0x12}},
+			{"Int16_4", -0x1234, []byte{0xcc, 0xed}},
+			{"Int16_5", math.MaxInt16, []byte{0xff, 0x7f}},
+			{"Int16_6", math.MinInt16, []byte{0x00, 0x80}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewLittleEndianWriter(buf)
+				_, err := w.WriteInt16(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt16() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt16() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt32
+	t.Run("WriteInt32", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int32
+			want  []byte
+		}{
+			{"Int32_1", 0, []byte{0x00, 0x00, 0x00, 0x00}},
+			{"Int32_2", -1, []byte{0xff, 0xff, 0xff, 0xff}},
+			{"Int32_3", 0x12345678, []byte{0x78, 0x56, 0x34, 0x12}},
+			{"Int32_4", -0x12345678, []byte{0x88, 0xa9, 0xcb, 0xed}},
+			{"Int32_5", math.MaxInt32, []byte{0xff, 0xff, 0xff, 0x7f}},
+			{"Int32_6", math.MinInt32, []byte{0x00, 0x00, 0x00, 0x80}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewLittleEndianWriter(buf)
+				_, err := w.WriteInt32(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt32() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt32() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	// Test WriteInt64
+	t.Run("WriteInt64", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			value int64
+			want  []byte
+		}{
+			{"Int64_1", 0, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+			{"Int64_2", -1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			{"Int64_3", 0x123456789ABCDEF0, []byte{0xf0, 0xde, 0xbc, 0x9a, 0x78, 0x56, 0x34, 0x12}},
+			{"Int64_4", -0x123456789ABCDEF0, []byte{0x10, 0x21, 0x43, 0x65, 0x87, 0xa9, 0xcb, 0xed}},
+			{"Int64_5", math.MaxInt64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+			{"Int64_6", math.MinInt64, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w := NewLittleEndianWriter(buf)
+				_, err := w.WriteInt64(tt.value)
+				if err != nil {
+					t.Errorf("WriteInt64() error = %v", err)
+					return
+				}
+				got := buf.Bytes()
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("WriteInt64() got = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
 	// Test error cases
 	t.Run("ErrorCases", func(t *testing.T) {
 		// Test writing to a writer that fails
@@ -489,6 +782,11 @@ func TestLittleEndianWriter(t *testing.T) {
 			if err == nil {
 				t.Errorf("WriteUint64() expected error for failing writer")
 			}
+
+			_, err = w.WriteInt32(-0x12345678)
+			if err == nil {
+				t.Errorf("WriteInt32() expected error for failing writer")
+			}
 		})
 	})
 }